@@ -2,12 +2,20 @@ package dephub
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 
+	"github.com/dephub/dephub-core/providers/api/conda"
+	"github.com/dephub/dephub-core/providers/api/crates"
+	"github.com/dephub/dephub-core/providers/api/maven"
 	"github.com/dephub/dephub-core/providers/api/packagist"
 	"github.com/dephub/dephub-core/providers/api/pip"
+	"github.com/dephub/dephub-core/providers/parsers"
+	"github.com/dephub/dephub-core/providers/transport"
 	"github.com/dephub/dephub-core/providers/versioneer"
 )
 
@@ -27,21 +35,210 @@ type Update struct {
 	URL               string `json:"url"`
 	CurrentVersion    string `json:"current_version,omitempty"`
 	CurrentConstraint string `json:"constraint,omitempty"`
+	// Index is the packages index (registry) address the update was found in (if the checker records it).
+	Index string `json:"index,omitempty"`
+	// Skipped lists newer versions which were not suggested (e.g. yanked ones) with the reasons,
+	// Version is empty if every version is skipped.
+	Skipped []SkippedVersion `json:"skipped,omitempty"`
+}
+
+// SkippedVersion represents the version skipped by the checker.
+type SkippedVersion struct {
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+}
+
+// AbandonedChecker represents abandoned (no longer maintained) packages checker.
+type AbandonedChecker interface {
+	// Abandoned returns abandoned packages of the locked dependencies.
+	Abandoned(ctx context.Context, requirements []Requirement) ([]AbandonedPackage, error)
+}
+
+// AbandonedPackage represents abandoned dependency.
+type AbandonedPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Replacement is the package suggested instead of the abandoned one (if any).
+	Replacement string `json:"replacement,omitempty"`
+	// Direct indicates the dependency is required by the project itself (not transitively).
+	Direct bool `json:"direct"`
+}
+
+// pyPiURL - PyPi address recorded in the updates found with the default checker.
+const pyPiURL = "https://pypi.org"
+
+// PIPOption configures PIPUpdatesChecker.
+type PIPOption func(uc *PIPUpdatesChecker)
+
+// WithPythonVersion sets the project target interpreter version (e.g. '3.8'), releases requiring
+// other Python versions ('requires_python') are skipped. Python version is not checked by default.
+func WithPythonVersion(version string) PIPOption {
+	return func(uc *PIPUpdatesChecker) {
+		uc.pythonVersion = version
+	}
 }
 
 // NewPIPUpdatesChecker constructs new PIPUpdatesChecker.
-func NewPIPUpdatesChecker(httpClient *http.Client) UpdatesChecker {
+func NewPIPUpdatesChecker(httpClient *http.Client, opts ...PIPOption) UpdatesChecker {
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		httpClient = transport.DefaultClient
 	}
 	api := pip.NewPyPiClient(httpClient, nil)
 
-	return &PIPUpdatesChecker{api: api}
+	uc := &PIPUpdatesChecker{api: api, index: pyPiURL}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// NewPIPSimpleUpdatesChecker constructs new PIPUpdatesChecker using Simple Repository API (PEP 503/691) index,
+// if indexURL is nil - PyPi Simple API is used.
+func NewPIPSimpleUpdatesChecker(httpClient *http.Client, indexURL *url.URL, opts ...PIPOption) UpdatesChecker {
+	if indexURL == nil {
+		indexURL, _ = url.Parse(pyPiURL + "/simple/")
+	}
+	return NewPIPIndexesUpdatesChecker(httpClient, []PIPIndex{{URL: indexURL}}, opts...)
+}
+
+// PIPIndex represents PIP packages index with Simple Repository API (e.g. '--index-url' or '--extra-index-url' value).
+type PIPIndex struct {
+	URL *url.URL
+	// Username and Password are the index credentials, URL user info is used if they are empty.
+	Username string
+	Password string
+}
+
+// String returns the index URL without credentials.
+func (pi PIPIndex) String() string {
+	if pi.URL == nil {
+		return ""
+	}
+	u := *pi.URL
+	u.User = nil
+	return u.String()
+}
+
+// PIPIndexes returns the indexes configured in the source pip requirements files ('--index-url' and '--extra-index-url'
+// options) in priority order, PyPi is the primary index if only extra indexes are configured. Nil is returned
+// if the files don't configure indexes (PyPi is used).
+//
+// Credentials are not read from the environment (unlike pip does), set PIPIndex credentials explicitly.
+func PIPIndexes(ctx context.Context, source DependencySource) ([]PIPIndex, error) {
+	fs, ok := source.(fileSource)
+	if !ok {
+		return nil, fmt.Errorf("source doesn't provide package manager files")
+	}
+	fetcher, cfg := fs.files()
+	files, err := projectManifests(ctx, PIPType, fetcher, cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var primary string
+	var extra []string
+	for _, file := range files {
+		parser, ok := parsers.NewPipParser(fetcher, file).(*parsers.PipParser)
+		if !ok {
+			continue
+		}
+		indexes, err := parser.Indexes(ctx)
+		if err == parsers.ErrFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if indexes.IndexURL != "" && primary == "" {
+			primary = indexes.IndexURL
+		}
+		extra = append(extra, indexes.ExtraIndexURLs...)
+	}
+	if primary == "" && len(extra) == 0 {
+		return nil, nil
+	}
+	if primary == "" {
+		primary = pyPiURL + "/simple/"
+	}
+
+	var result []PIPIndex
+	seen := map[string]bool{}
+	for _, raw := range append([]string{primary}, extra...) {
+		if seen[raw] {
+			continue
+		}
+		seen[raw] = true
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %q index url: %w", raw, err)
+		}
+		result = append(result, PIPIndex{URL: u})
+	}
+	return result, nil
+}
+
+// NewPIPIndexesUpdatesChecker constructs new PIPUpdatesChecker looking up packages in the indexes in priority order
+// (primary index followed by the extra ones), the first index providing the package is used and recorded in the updates.
+//
+// Unlike pip, versions of the same package are not merged from different indexes, so public packages can't shadow
+// the private ones. If no indexes are passed - PyPi is used.
+func NewPIPIndexesUpdatesChecker(httpClient *http.Client, indexes []PIPIndex, opts ...PIPOption) UpdatesChecker {
+	if len(indexes) == 0 {
+		return NewPIPUpdatesChecker(httpClient, opts...)
+	}
+	if httpClient == nil {
+		httpClient = transport.DefaultClient
+	}
+
+	uc := &PIPUpdatesChecker{}
+	for i, index := range indexes {
+		u := *index.URL
+		if index.Username != "" || index.Password != "" {
+			u.User = url.UserPassword(index.Username, index.Password)
+		}
+		api := pip.NewSimpleClient(httpClient, &u)
+		if i == 0 {
+			uc.api, uc.index = api, index.String()
+			continue
+		}
+		uc.extraIndexes = append(uc.extraIndexes, pipIndexClient{api: api, index: index.String()})
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 // PIPUpdatesChecker represents PIP packages update checker.
 type PIPUpdatesChecker struct {
 	api pip.Client
+	// index - api index address recorded in the updates
+	index string
+	// extraIndexes - indexes looked up (in order) if the package is not found with api
+	extraIndexes []pipIndexClient
+	// pythonVersion - project target interpreter version, releases requiring other Python versions are skipped
+	pythonVersion string
+}
+
+// pipIndexClient represents extra index client.
+type pipIndexClient struct {
+	api   pip.Client
+	index string
+}
+
+// release - helper to get the package releases from the first index providing it, the index address is returned as well.
+func (uc PIPUpdatesChecker) release(ctx context.Context, name string) (*pip.PipPackage, string, error) {
+	meta, _, err := uc.api.Release(ctx, name, "")
+	if err == nil || !errors.Is(err, pip.ErrNotFound) {
+		return meta, uc.index, err
+	}
+	for _, extra := range uc.extraIndexes {
+		meta, _, err = extra.api.Release(ctx, name, "")
+		if err == nil || !errors.Is(err, pip.ErrNotFound) {
+			return meta, extra.index, err
+		}
+	}
+	return nil, "", err
 }
 
 // CompatibleUpdates returns latest available updates for locked dependencies compatible with constraints.
@@ -67,7 +264,7 @@ skip_pkg:
 	for _, pkg := range packages {
 		update := &Update{}
 
-		meta, _, err := uc.api.Release(ctx, pkg.Name, "")
+		meta, index, err := uc.release(ctx, pkg.Name)
 		if err != nil {
 			continue
 		}
@@ -77,17 +274,25 @@ skip_pkg:
 			continue
 		}
 
+		var skipped []SkippedVersion
 		for i := len(meta.Releases) - 1; i >= 0; i-- {
+			// Pre-releases are never suggested
 			vers, err := versioneer.NewPipVersion(meta.Releases[i].Version)
-			if err != nil {
+			if err != nil || vers.(versioneer.PipVersion).Prerelease() {
+				continue
+			}
+			if reason := uc.skipReason(meta.Releases[i]); reason != "" {
+				skipped = append(skipped, SkippedVersion{Version: meta.Releases[i].Version, Reason: reason})
 				continue
 			}
 
+			update.Skipped = skipped
 			update.Name = pkg.Name
 			update.Version = meta.Releases[i].Version
 			update.Author = meta.Info.Author
 			update.URL = meta.Info.ReleaseURL
 			update.CurrentConstraint = pkg.Version
+			update.Index = index
 
 			// If we only need incompatible versions and the last version matches the constraint
 			// then skip the package, it is already up do date
@@ -98,7 +303,16 @@ skip_pkg:
 			break
 		}
 
-		if update != nil {
+		// Nothing to suggest, but the reasons are reported
+		if update.Version == "" && len(skipped) > 0 {
+			update.Skipped = skipped
+			update.Name = pkg.Name
+			update.Author = meta.Info.Author
+			update.URL = meta.Info.ReleaseURL
+			update.CurrentConstraint = pkg.Version
+			update.Index = index
+		}
+		if update.Version != "" || len(update.Skipped) > 0 {
 			result = append(result, *update)
 		}
 	}
@@ -106,10 +320,55 @@ skip_pkg:
 	return result, nil
 }
 
+// skipReason returns the reason the release can't be installed: every release file is yanked or requires
+// Python version excluding the target one (if it's set). Empty string is returned for installable releases.
+func (uc PIPUpdatesChecker) skipReason(release pip.PipPackageVersion) string {
+	if len(release.Releases) == 0 {
+		return ""
+	}
+
+	yanked, yankedReason := true, ""
+	for _, file := range release.Releases {
+		if !file.Yanked {
+			yanked = false
+			break
+		}
+		if yankedReason == "" {
+			yankedReason = file.YankedReason
+		}
+	}
+	if yanked {
+		if yankedReason != "" {
+			return fmt.Sprintf("yanked: %s", yankedReason)
+		}
+		return "yanked"
+	}
+
+	if uc.pythonVersion == "" {
+		return ""
+	}
+	python, err := versioneer.NewPipVersion(uc.pythonVersion)
+	if err != nil {
+		return ""
+	}
+	var requires string
+	for _, file := range release.Releases {
+		if file.Yanked {
+			continue
+		}
+		constraint, err := versioneer.NewPipConstraints(file.RequiresPython)
+		if file.RequiresPython == "" || err != nil || constraint.Match(python) {
+			return ""
+		}
+		requires = file.RequiresPython
+	}
+	return fmt.Sprintf("requires python %q (target is %q)", requires, uc.pythonVersion)
+}
+
 // NewComposerUpdatesChecker constructs new ComposerUpdatesChecker.
 func NewComposerUpdatesChecker(httpClient *http.Client) UpdatesChecker {
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		httpClient = transport.DefaultClient
 	}
 	api, err := packagist.NewClient(httpClient, nil)
 	if err != nil {
@@ -119,9 +378,15 @@ func NewComposerUpdatesChecker(httpClient *http.Client) UpdatesChecker {
 	return &ComposerUpdatesChecker{api: api}
 }
 
+// NewComposerRepositoriesChecker constructs new ComposerUpdatesChecker looking up packages in the repositories
+// in priority order (as Composer does), e.g. private Satis repository followed by Packagist.
+func NewComposerRepositoriesChecker(repos ...packagist.MetaClient) UpdatesChecker {
+	return &ComposerUpdatesChecker{api: packagist.NewRepositoryChain(repos...)}
+}
+
 // ComposerUpdatesChecker represents Composer packages update checker.
 type ComposerUpdatesChecker struct {
-	api packagist.Client
+	api packagist.MetaClient
 }
 
 // CompatibleUpdates returns latest available updates for locked dependencies compatible with constraints.
@@ -256,7 +521,7 @@ skip_pkg:
 }
 
 // getPackagistMeta returns meta information about the package from packagist api.
-func (uc ComposerUpdatesChecker) getPackagistMeta(ctx context.Context, cl packagist.Client, pkg string) (packagist.PackageMeta, error) {
+func (uc ComposerUpdatesChecker) getPackagistMeta(ctx context.Context, cl packagist.MetaClient, pkg string) (packagist.PackageMeta, error) {
 	pkgNamePrts := strings.Split(pkg, "/")
 	if len(pkgNamePrts) != 2 {
 		return nil, fmt.Errorf("cannot parse vendor from package name %q", pkg)
@@ -268,11 +533,88 @@ func (uc ComposerUpdatesChecker) getPackagistMeta(ctx context.Context, cl packag
 	}
 
 	if _, ok := metaData.Packages[pkg]; !ok {
-		return nil, fmt.Errorf("package %q not found on packagist", pkg)
+		return nil, fmt.Errorf("package %q not found on packagist: %w", pkg, packagist.ErrNotFound)
 	}
 	return metaData.Packages[pkg], err
 }
 
+// NewComposerAbandonedChecker constructs new ComposerUpdatesChecker used to check abandoned packages.
+func NewComposerAbandonedChecker(httpClient *http.Client) AbandonedChecker {
+	return NewComposerUpdatesChecker(httpClient).(*ComposerUpdatesChecker)
+}
+
+// Abandoned returns abandoned packages of the locked dependencies (direct and transitive ones from composer.lock),
+// packages missing in the repositories are skipped.
+func (uc ComposerUpdatesChecker) Abandoned(ctx context.Context, requirements []Requirement) ([]AbandonedPackage, error) {
+	var result []AbandonedPackage
+	for _, req := range requirements {
+		metaData, err := uc.getPackagistMeta(ctx, uc.api, req.Name)
+		if errors.Is(err, packagist.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to check %q package: %w", req.Name, err)
+		}
+
+		// The flag is set for the whole package, but some versions (e.g. dev branches) may miss it
+		abandoned := abandonedFlag(metaData)
+		if !abandoned.Abandoned {
+			continue
+		}
+		result = append(result, AbandonedPackage{
+			Name:        req.Name,
+			Version:     req.Version,
+			Replacement: abandoned.Replacement,
+			Direct:      req.Base,
+		})
+	}
+
+	return result, nil
+}
+
+// abandonedFlag - helper to get the package abandoned flag set on any of the versions, the version suggesting
+// the replacement is preferred.
+func abandonedFlag(metaData packagist.PackageMeta) packagist.Abandoned {
+	var res packagist.Abandoned
+	for i := len(metaData) - 1; i >= 0; i-- {
+		if !metaData[i].Abandoned.Abandoned {
+			continue
+		}
+		if metaData[i].Abandoned.Replacement != "" {
+			return metaData[i].Abandoned
+		}
+		res = metaData[i].Abandoned
+	}
+	return res
+}
+
+// ComposerChangesUpdates returns last releases of the watched packages updated according to the Packagist metadata changes
+// (see packagist.ChangesFeed), every watched package is checked if the changes require resync. Deleted packages are skipped.
+func ComposerChangesUpdates(ctx context.Context, checker UpdatesChecker, changes *packagist.MetadataChanges, watched []Constraint, incompatibleOnly bool) ([]Update, error) {
+	if changes == nil {
+		return nil, nil
+	}
+
+	changed := watched
+	if !changes.Resync() {
+		updated := map[string]bool{}
+		for _, name := range changes.Updated() {
+			updated[strings.ToLower(name)] = true
+		}
+		changed = nil
+		for _, cns := range watched {
+			if updated[strings.ToLower(cns.Name)] {
+				changed = append(changed, cns)
+			}
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	return checker.LastUpdates(ctx, changed, incompatibleOnly)
+}
+
 // composerVersionToUpdate is a little helper to convert VersionMeta to Update type.
 func composerVersionToUpdate(release packagist.VersionMeta) *Update {
 	update := &Update{
@@ -287,3 +629,524 @@ func composerVersionToUpdate(release packagist.VersionMeta) *Update {
 	}
 	return update
 }
+
+// NewCargoUpdatesChecker constructs new CargoUpdatesChecker.
+func NewCargoUpdatesChecker(httpClient *http.Client) UpdatesChecker {
+	if httpClient == nil {
+		httpClient = transport.DefaultClient
+	}
+	api := crates.NewSparseIndexClient(httpClient, nil)
+
+	return &CargoUpdatesChecker{api: api}
+}
+
+// CargoUpdatesChecker represents Cargo crates update checker.
+type CargoUpdatesChecker struct {
+	api crates.Client
+}
+
+// CompatibleUpdates returns latest available updates for locked dependencies compatible with constraints.
+//
+// Basically it is 'your locked dependency is lower then available with your constraints'
+func (uc CargoUpdatesChecker) CompatibleUpdates(ctx context.Context, constraints []Constraint, requirements []Requirement) ([]Update, error) {
+	if len(requirements) == 0 || len(constraints) == 0 {
+		return nil, fmt.Errorf("no packages provided")
+	}
+
+	// To optimize requirements filtering
+	reqsLookup := make(map[string]*Requirement)
+	for i, req := range requirements {
+		reqsLookup[req.Name] = &requirements[i]
+	}
+
+	result := make([]Update, 0, len(constraints))
+
+	for _, cns := range constraints {
+		req, ok := reqsLookup[cns.Name]
+		if !ok {
+			continue
+		}
+
+		crate, _, err := uc.api.Crate(ctx, cns.Name)
+		if err != nil {
+			continue
+		}
+
+		baseCst, err := versioneer.NewCargoConstraints(cns.Version)
+		if err != nil {
+			continue
+		}
+		reqCst, err := versioneer.NewCargoConstraints(">" + req.Version)
+		if err != nil {
+			continue
+		}
+
+		// Filter first (from the newest) not yanked version satisfying the constraint
+		for i := len(crate.Versions) - 1; i >= 0; i-- {
+			if crate.Versions[i].Yanked {
+				continue
+			}
+			vers, err := versioneer.NewCargoVersion(crate.Versions[i].Version)
+			if err != nil {
+				continue
+			}
+
+			if baseCst.Match(vers) && reqCst.Match(vers) {
+				update := crateVersionToUpdate(crate.Versions[i])
+				update.CurrentVersion = req.Version
+				update.CurrentConstraint = cns.Version
+				result = append(result, *update)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// LastUpdates returns latest versions for each package
+func (uc CargoUpdatesChecker) LastUpdates(ctx context.Context, packages []Constraint, incompatibleOnly bool) ([]Update, error) {
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no packages provided")
+	}
+
+	result := make([]Update, 0, len(packages))
+
+skip_pkg:
+	for _, pkg := range packages {
+		crate, _, err := uc.api.Crate(ctx, pkg.Name)
+		if err != nil {
+			continue
+		}
+
+		constraint, err := versioneer.NewCargoConstraints(pkg.Version)
+		if err != nil {
+			continue
+		}
+
+		var update *Update
+		// Filter first (from the newest) not yanked stable version
+		for i := len(crate.Versions) - 1; i >= 0; i-- {
+			if crate.Versions[i].Yanked {
+				continue
+			}
+			vers, err := versioneer.NewCargoVersion(crate.Versions[i].Version)
+			if err != nil || vers.(versioneer.CargoVersion).Prerelease() != "" {
+				continue
+			}
+
+			// If we only need incompatible versions and the last version matches the constraint
+			// then skip the package, it is already up do date
+			if incompatibleOnly && constraint.Match(vers) {
+				continue skip_pkg
+			}
+
+			update = crateVersionToUpdate(crate.Versions[i])
+			break
+		}
+
+		if update != nil {
+			update.CurrentConstraint = pkg.Version
+			result = append(result, *update)
+		}
+	}
+
+	return result, nil
+}
+
+// crateVersionToUpdate is a little helper to convert CrateVersion to Update type.
+//
+// The registry index has no authors information, so crate name is used instead.
+func crateVersionToUpdate(release crates.CrateVersion) *Update {
+	return &Update{
+		Name:    release.Name,
+		URL:     "https://crates.io/crates/" + release.Name,
+		Version: release.Version,
+		Author:  release.Name,
+	}
+}
+
+// NewMavenUpdatesChecker constructs new MavenUpdatesChecker.
+//
+// If repoURL is nil - Maven Central repository will be used.
+// Any repository with the standard Maven 2 layout (Nexus, Artifactory, etc.) can be passed instead.
+func NewMavenUpdatesChecker(httpClient *http.Client, repoURL *url.URL) UpdatesChecker {
+	if httpClient == nil {
+		httpClient = transport.DefaultClient
+	}
+	api := maven.NewRepositoryClient(httpClient, repoURL)
+
+	uc := &MavenUpdatesChecker{api: api}
+	if rc, ok := api.(*maven.RepositoryClient); ok {
+		base := rc.BaseURL()
+		uc.repoURL = strings.TrimSuffix(base.String(), "/")
+	}
+	return uc
+}
+
+// MavenUpdatesChecker represents Maven artifacts update checker.
+//
+// Packages names are expected in the 'groupId:artifactId' format.
+type MavenUpdatesChecker struct {
+	api     maven.Client
+	repoURL string
+}
+
+// CompatibleUpdates returns latest available updates for locked dependencies compatible with constraints.
+//
+// Basically it is 'your locked dependency is lower then available with your constraints'
+func (uc MavenUpdatesChecker) CompatibleUpdates(ctx context.Context, constraints []Constraint, requirements []Requirement) ([]Update, error) {
+	if len(requirements) == 0 || len(constraints) == 0 {
+		return nil, fmt.Errorf("no packages provided")
+	}
+
+	// To optimize requirements filtering
+	reqsLookup := make(map[string]*Requirement)
+	for i, req := range requirements {
+		reqsLookup[req.Name] = &requirements[i]
+	}
+
+	result := make([]Update, 0, len(constraints))
+
+	for _, cns := range constraints {
+		req, ok := reqsLookup[cns.Name]
+		if !ok {
+			continue
+		}
+
+		versions, err := uc.versions(ctx, cns.Name)
+		if err != nil {
+			continue
+		}
+
+		baseCst, err := versioneer.NewMavenConstraints(cns.Version)
+		if err != nil {
+			continue
+		}
+		reqVers, err := versioneer.NewMavenVersion(req.Version)
+		if err != nil {
+			continue
+		}
+
+		current := reqVers.(versioneer.MavenVersion)
+
+		// Filter first (from the newest) version satisfying the constraint,
+		// pre-releases are suggested only if the current version is a pre-release as well
+		for i := len(versions) - 1; i >= 0; i-- {
+			if versions[i].Compare(current) <= 0 {
+				break
+			}
+			if versions[i].Prerelease() && !current.Prerelease() {
+				continue
+			}
+			if baseCst.Match(versions[i]) {
+				update := uc.versionToUpdate(cns.Name, versions[i].Value())
+				update.CurrentVersion = req.Version
+				update.CurrentConstraint = cns.Version
+				result = append(result, *update)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// LastUpdates returns latest versions for each package
+func (uc MavenUpdatesChecker) LastUpdates(ctx context.Context, packages []Constraint, incompatibleOnly bool) ([]Update, error) {
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no packages provided")
+	}
+
+	result := make([]Update, 0, len(packages))
+
+skip_pkg:
+	for _, pkg := range packages {
+		versions, err := uc.versions(ctx, pkg.Name)
+		if err != nil {
+			continue
+		}
+
+		constraint, err := versioneer.NewMavenConstraints(pkg.Version)
+		if err != nil {
+			continue
+		}
+
+		var update *Update
+		// Filter first (from the newest) stable version
+		for i := len(versions) - 1; i >= 0; i-- {
+			if versions[i].Prerelease() {
+				continue
+			}
+
+			// If we only need incompatible versions and the last version matches the constraint
+			// then skip the package, it is already up do date
+			if incompatibleOnly && constraint.Match(versions[i]) {
+				continue skip_pkg
+			}
+
+			update = uc.versionToUpdate(pkg.Name, versions[i].Value())
+			break
+		}
+
+		if update != nil {
+			update.CurrentConstraint = pkg.Version
+			result = append(result, *update)
+		}
+	}
+
+	return result, nil
+}
+
+// versions returns artifact versions sorted from the oldest to the newest.
+//
+// Repository metadata doesn't guarantee versions order, so they are sorted using maven ordering rules.
+func (uc MavenUpdatesChecker) versions(ctx context.Context, name string) ([]versioneer.MavenVersion, error) {
+	parts := strings.SplitN(name, ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unsupported maven package name %q", name)
+	}
+
+	md, _, err := uc.api.Metadata(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]versioneer.MavenVersion, 0, len(md.Versioning.Versions))
+	for _, raw := range md.Versioning.Versions {
+		vers, err := versioneer.NewMavenVersion(raw)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, vers.(versioneer.MavenVersion))
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) < 0
+	})
+
+	return versions, nil
+}
+
+// versionToUpdate is a little helper to build Update for an artifact version.
+//
+// The repository metadata has no authors information, so package name is used instead.
+func (uc MavenUpdatesChecker) versionToUpdate(name, version string) *Update {
+	parts := strings.SplitN(name, ":", 2)
+	return &Update{
+		Name:    name,
+		URL:     uc.repoURL + "/" + maven.ArtifactPath(parts[0], parts[1]) + "/" + version + "/",
+		Version: version,
+		Author:  name,
+	}
+}
+
+// condaDefaultSubdirs - platforms checked by CondaUpdatesChecker by default.
+var condaDefaultSubdirs = []string{"noarch", "linux-64"}
+
+// NewCondaUpdatesChecker constructs new CondaUpdatesChecker.
+//
+// If channelsURL is nil - anaconda.org channels will be used, pass a mirror address
+// (or use NewCondaMirrorUpdatesChecker for the local one) to avoid downloading large channels indexes.
+func NewCondaUpdatesChecker(httpClient *http.Client, channelsURL *url.URL) UpdatesChecker {
+	if httpClient == nil {
+		httpClient = transport.DefaultClient
+	}
+	api := conda.NewChannelClient(httpClient, channelsURL)
+
+	return &CondaUpdatesChecker{api: api, Subdirs: condaDefaultSubdirs}
+}
+
+// NewCondaMirrorUpdatesChecker constructs new CondaUpdatesChecker reading channels from the local mirror directory.
+func NewCondaMirrorUpdatesChecker(dir string) UpdatesChecker {
+	return &CondaUpdatesChecker{api: conda.NewLocalChannelClient(dir), Subdirs: condaDefaultSubdirs}
+}
+
+// CondaUpdatesChecker represents conda packages update checker.
+//
+// Packages names are expected in the 'channel::name' format.
+type CondaUpdatesChecker struct {
+	api conda.Client
+	// Subdirs is a list of platforms (e.g. 'noarch' or 'linux-64') to look the packages in
+	Subdirs []string
+}
+
+// CompatibleUpdates returns latest available updates for locked dependencies compatible with constraints.
+//
+// Basically it is 'your locked dependency is lower then available with your constraints'
+func (uc CondaUpdatesChecker) CompatibleUpdates(ctx context.Context, constraints []Constraint, requirements []Requirement) ([]Update, error) {
+	if len(requirements) == 0 || len(constraints) == 0 {
+		return nil, fmt.Errorf("no packages provided")
+	}
+
+	// To optimize requirements filtering
+	reqsLookup := make(map[string]*Requirement)
+	for i, req := range requirements {
+		reqsLookup[req.Name] = &requirements[i]
+	}
+
+	result := make([]Update, 0, len(constraints))
+	repos := map[string]*conda.RepoData{}
+
+	for _, cns := range constraints {
+		req, ok := reqsLookup[cns.Name]
+		if !ok {
+			continue
+		}
+
+		versions, err := uc.versions(ctx, repos, cns.Name)
+		if err != nil {
+			continue
+		}
+
+		baseCst, err := versioneer.NewCondaConstraints(cns.Version)
+		if err != nil {
+			continue
+		}
+		reqVers, err := versioneer.NewCondaVersion(req.Version)
+		if err != nil {
+			continue
+		}
+		current := reqVers.(versioneer.CondaVersion)
+
+		// Filter first (from the newest) version satisfying the constraint,
+		// pre-releases are suggested only if the current version is a pre-release as well
+		for i := len(versions) - 1; i >= 0; i-- {
+			if versions[i].Compare(current) <= 0 {
+				break
+			}
+			if versions[i].Prerelease() && !current.Prerelease() {
+				continue
+			}
+			if baseCst.Match(versions[i]) {
+				update := condaVersionToUpdate(cns.Name, versions[i].Value())
+				update.CurrentVersion = req.Version
+				update.CurrentConstraint = cns.Version
+				result = append(result, *update)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// LastUpdates returns latest versions for each package
+func (uc CondaUpdatesChecker) LastUpdates(ctx context.Context, packages []Constraint, incompatibleOnly bool) ([]Update, error) {
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no packages provided")
+	}
+
+	result := make([]Update, 0, len(packages))
+	repos := map[string]*conda.RepoData{}
+
+skip_pkg:
+	for _, pkg := range packages {
+		versions, err := uc.versions(ctx, repos, pkg.Name)
+		if err != nil {
+			continue
+		}
+
+		constraint, err := versioneer.NewCondaConstraints(pkg.Version)
+		if err != nil {
+			continue
+		}
+
+		var update *Update
+		// Filter first (from the newest) stable version
+		for i := len(versions) - 1; i >= 0; i-- {
+			if versions[i].Prerelease() {
+				continue
+			}
+
+			// If we only need incompatible versions and the last version matches the constraint
+			// then skip the package, it is already up do date
+			if incompatibleOnly && constraint.Match(versions[i]) {
+				continue skip_pkg
+			}
+
+			update = condaVersionToUpdate(pkg.Name, versions[i].Value())
+			break
+		}
+
+		if update != nil {
+			update.CurrentConstraint = pkg.Version
+			result = append(result, *update)
+		}
+	}
+
+	return result, nil
+}
+
+// versions returns package versions from all the checked subdirs sorted from the oldest to the newest.
+//
+// Channels indexes are large, so fetched ones are kept in the repos map and reused for the next packages.
+func (uc CondaUpdatesChecker) versions(ctx context.Context, repos map[string]*conda.RepoData, name string) ([]versioneer.CondaVersion, error) {
+	parts := strings.SplitN(name, "::", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unsupported conda package name %q", name)
+	}
+	channel, pkg := parts[0], parts[1]
+
+	seen := map[string]struct{}{}
+	var versions []versioneer.CondaVersion
+	var lastErr error
+	found := false
+	for _, subdir := range uc.Subdirs {
+		key := channel + "/" + subdir
+		rd, ok := repos[key]
+		if !ok {
+			var err error
+			rd, _, err = uc.api.RepoData(ctx, channel, subdir)
+			if err != nil {
+				// Channels can miss some of the platforms
+				lastErr = err
+				continue
+			}
+			repos[key] = rd
+		}
+		found = true
+
+		for _, rec := range rd.Records(pkg) {
+			if _, ok := seen[rec.Version]; ok {
+				continue
+			}
+			seen[rec.Version] = struct{}{}
+			vers, err := versioneer.NewCondaVersion(rec.Version)
+			if err != nil {
+				continue
+			}
+			versions = append(versions, vers.(versioneer.CondaVersion))
+		}
+	}
+	if !found {
+		return nil, lastErr
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) < 0
+	})
+
+	return versions, nil
+}
+
+// condaVersionToUpdate is a little helper to build Update for a package version.
+//
+// The channel index has no authors information, so package name is used instead.
+func condaVersionToUpdate(name, version string) *Update {
+	parts := strings.SplitN(name, "::", 2)
+	channel, pkg := parts[0], parts[1]
+	URL := "https://anaconda.org/" + channel + "/" + pkg
+	switch true {
+	case channel == "defaults":
+		URL = "https://anaconda.org/anaconda/" + pkg
+	case strings.Contains(channel, "://"):
+		URL = strings.TrimSuffix(channel, "/")
+	}
+
+	return &Update{
+		Name:    name,
+		URL:     URL,
+		Version: version,
+		Author:  pkg,
+	}
+}
@@ -0,0 +1,142 @@
+package dephub
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+// GitProvider constructs files fetcher of the repository hosted by the git provider (e.g. GitHub or GitLab).
+//
+// repoPath is the full repository path (e.g. 'vendor/repo' or 'group/subgroup/repo' for GitLab nested groups).
+type GitProvider func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error)
+
+// gitHosts - registered git hosts providers (host:provider), see RegisterGitHost.
+var gitHosts = map[string]GitProvider{
+	"github.com":    GitHubProvider(),
+	"gitlab.com":    GitLabProvider(nil, ""),
+	"bitbucket.org": BitbucketProvider(nil, ""),
+	"codeberg.org":  GiteaProvider(nil, ""),
+}
+
+// gitHostsMu guards gitHosts registry.
+var gitHostsMu sync.RWMutex
+
+// RegisterGitHost registers git provider of the host (e.g. 'gitlab.example.com') for every git source,
+// registered provider replaces the previous one. Use WithGitHost option to configure single source.
+func RegisterGitHost(host string, provider GitProvider) {
+	gitHostsMu.Lock()
+	defer gitHostsMu.Unlock()
+	gitHosts[host] = provider
+}
+
+// WithGitHost configures git provider of the host for the source (it takes precedence over RegisterGitHost registry).
+func WithGitHost(host string, provider GitProvider) SourceOption {
+	return func(cfg *sourceConfig) {
+		if cfg.gitHosts == nil {
+			cfg.gitHosts = map[string]GitProvider{}
+		}
+		cfg.gitHosts[host] = provider
+	}
+}
+
+// WithGitLabHost registers self-hosted GitLab instance host (e.g. 'gitlab.example.com') for NewGitSource.
+//
+// apiURL is the instance API v4 address (e.g. 'https://gitlab.example.com/api/v4/'), if nil - 'https://{host}/api/v4/' is used.
+// Token is sent as private/personal access token (if not empty), it can be used for 'gitlab.com' host too.
+func WithGitLabHost(host string, apiURL *url.URL, token string) SourceOption {
+	if apiURL == nil {
+		apiURL = &url.URL{Scheme: "https", Host: host, Path: "/api/v4/"}
+	}
+	return WithGitHost(host, GitLabProvider(apiURL, token))
+}
+
+// WithGitHubEnterpriseHost registers GitHub Enterprise Server host (e.g. 'ghe.corp') for NewGitSource.
+//
+// If baseURL is empty - 'https://{host}/' is used, if uploadURL is empty - baseURL is used.
+func WithGitHubEnterpriseHost(host, baseURL, uploadURL string) SourceOption {
+	if baseURL == "" {
+		baseURL = (&url.URL{Scheme: "https", Host: host, Path: "/"}).String()
+	}
+	return WithGitHost(host, GitHubEnterpriseProvider(baseURL, uploadURL))
+}
+
+// GitHubProvider returns GitHub (github.com) repositories provider.
+// Use signed httpClient (e.g. OAuth2) for authentication.
+func GitHubProvider() GitProvider {
+	return func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error) {
+		owner, repo := splitRepoPath(repoPath)
+		return fetchers.NewGitHubFetcher(httpClient, owner, repo, sha), nil
+	}
+}
+
+// GitHubEnterpriseProvider returns GitHub Enterprise Server repositories provider.
+//
+// baseURL and uploadURL are the enterprise instance addresses (e.g. 'https://ghe.corp/'), see fetchers.NewGitHubEnterpriseFetcher.
+func GitHubEnterpriseProvider(baseURL, uploadURL string) GitProvider {
+	return func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error) {
+		owner, repo := splitRepoPath(repoPath)
+		return fetchers.NewGitHubEnterpriseFetcher(httpClient, baseURL, uploadURL, owner, repo, sha)
+	}
+}
+
+// GitLabProvider returns GitLab repositories provider, if apiURL is nil - gitlab.com API is used.
+func GitLabProvider(apiURL *url.URL, token string) GitProvider {
+	return func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error) {
+		return fetchers.NewGitLabFetcher(httpClient, apiURL, repoPath, sha, token), nil
+	}
+}
+
+// BitbucketProvider returns Bitbucket Cloud repositories provider, if apiURL is nil - bitbucket.org API is used.
+func BitbucketProvider(apiURL *url.URL, token string) GitProvider {
+	return func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error) {
+		owner, repo := splitRepoPath(repoPath)
+		return fetchers.NewBitbucketFetcher(httpClient, apiURL, owner, repo, sha, token), nil
+	}
+}
+
+// BitbucketServerProvider returns Bitbucket Server (Data Center) repositories provider,
+// baseURL is the instance address (e.g. 'https://bitbucket.example.com/').
+//
+// Both HTTPS ('https://bitbucket.example.com/scm/PROJ/repo.git') and SSH ('ssh://git@bitbucket.example.com:7999/proj/repo.git')
+// clone addresses are supported.
+func BitbucketServerProvider(baseURL url.URL, token string) GitProvider {
+	return func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error) {
+		// HTTPS clone addresses are prefixed with 'scm/'
+		project, repo := splitRepoPath(strings.TrimPrefix(repoPath, "scm/"))
+		return fetchers.NewBitbucketServerFetcher(httpClient, baseURL, project, repo, sha, token), nil
+	}
+}
+
+// GiteaProvider returns Gitea/Forgejo repositories provider, if apiURL is nil - codeberg.org API is used.
+func GiteaProvider(apiURL *url.URL, token string) GitProvider {
+	return func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error) {
+		owner, repo := splitRepoPath(repoPath)
+		return fetchers.NewGiteaFetcher(httpClient, apiURL, owner, repo, sha, token), nil
+	}
+}
+
+// gitProvider returns configured git provider of the host (source options first, then the registry).
+func (cfg *sourceConfig) gitProvider(host string) (GitProvider, bool) {
+	if cfg != nil {
+		if provider, ok := cfg.gitHosts[host]; ok {
+			return provider, true
+		}
+	}
+	gitHostsMu.RLock()
+	defer gitHostsMu.RUnlock()
+	provider, ok := gitHosts[host]
+	return provider, ok
+}
+
+// splitRepoPath - helper to split '{owner}/{repo}' repository path.
+func splitRepoPath(repoPath string) (string, string) {
+	parts := strings.Split(repoPath, "/")
+	if len(parts) < 2 {
+		return repoPath, ""
+	}
+	return parts[0], parts[1]
+}
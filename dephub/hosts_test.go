@@ -0,0 +1,150 @@
+package dephub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+func TestGitHosts_Registry(t *testing.T) {
+	memProvider := func(httpClient *http.Client, repoPath, sha string) (fetchers.FileFetcher, error) {
+		if repoPath != "team/repo" || sha != "main" {
+			t.Errorf("unexpected provider arguments: %q, %q", repoPath, sha)
+		}
+		return fetchers.ByteMapFetcher{Files: fileMapMockData}, nil
+	}
+
+	_, err := NewGitSource(nil, "git@git.example.com:team/repo.git", "main")
+	if err == nil || err.Error() != `git source "git.example.com" is not supported` {
+		t.Fatalf("expected unsupported git source error before registration, got: %v", err)
+	}
+
+	// Source option
+	depSource, err := NewGitSource(nil, "git@git.example.com:team/repo.git", "main", WithGitHost("git.example.com", memProvider))
+	if err != nil {
+		t.Fatalf("unexpected error on new git source: %v", err)
+	}
+	if _, err := depSource.Constraints(context.Background(), ComposerType); err != nil {
+		t.Errorf("unexpected error on composer constraints: %v", err)
+	}
+
+	// Global registry
+	RegisterGitHost("git.example.com", memProvider)
+	defer func() {
+		gitHostsMu.Lock()
+		delete(gitHosts, "git.example.com")
+		gitHostsMu.Unlock()
+	}()
+	depSource, err = NewGitSource(nil, "https://git.example.com/team/repo.git", "main")
+	if err != nil {
+		t.Fatalf("unexpected error on new git source: %v", err)
+	}
+	if _, err := depSource.Constraints(context.Background(), PIPType); err != nil {
+		t.Errorf("unexpected error on pip constraints: %v", err)
+	}
+}
+
+func TestGitHosts_Providers(t *testing.T) {
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Host + r.URL.RequestURI() {
+		case "api.bitbucket.org/2.0/repositories/workspace/repo/src/main/composer.json":
+			_, _ = rw.Write(fileMapMockData["composer.json"])
+		case "gitea.example.com/api/v1/repos/org/repo/contents/composer.json?ref=main":
+			_, _ = rw.Write([]byte(`{"type": "file", "encoding": "base64", "content": "eyJyZXF1aXJlIjogeyJwaHAiOiAiXjguMCJ9fQ=="}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	giteaURL, _ := url.Parse("https://gitea.example.com/api/v1/")
+	cases := []struct {
+		Name string
+		Addr string
+		Opts []SourceOption
+	}{
+		{"bitbucket", "git@bitbucket.org:workspace/repo.git", nil},
+		{"gitea", "git@gitea.example.com:org/repo.git", []SourceOption{WithGitHost("gitea.example.com", GiteaProvider(giteaURL, ""))}},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Name, func(t *testing.T) {
+			depSource, err := NewGitSource(cl, cs.Addr, "main", cs.Opts...)
+			if err != nil {
+				t.Fatalf("unexpected error on new git source: %v", err)
+			}
+			cnsts, err := depSource.Constraints(context.Background(), ComposerType)
+			if err != nil {
+				t.Fatalf("unexpected error on composer constraints: %v", err)
+			}
+			if len(cnsts) == 0 {
+				t.Errorf("expected composer constraints, got none")
+			}
+		})
+	}
+}
+
+func TestGitHosts_BitbucketServer(t *testing.T) {
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Host + r.URL.Path {
+		case "bitbucket.example.com/rest/api/1.0/projects/PROJ/repos/repo/raw/composer.json",
+			"bitbucket.example.com/rest/api/1.0/projects/proj/repos/repo/raw/composer.json":
+			_, _ = rw.Write(fileMapMockData["composer.json"])
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	baseURL, _ := url.Parse("https://bitbucket.example.com/")
+	opt := WithGitHost("bitbucket.example.com", BitbucketServerProvider(*baseURL, ""))
+	for _, addr := range []string{
+		"https://bitbucket.example.com/scm/PROJ/repo.git",
+		"https://user@bitbucket.example.com:7990/scm/PROJ/repo.git",
+		"ssh://git@bitbucket.example.com:7999/proj/repo.git",
+		"ssh://bitbucket.example.com/proj/repo.git",
+	} {
+		t.Run(addr, func(t *testing.T) {
+			depSource, err := NewGitSource(cl, addr, "main", opt)
+			if err != nil {
+				t.Fatalf("unexpected error on new git source: %v", err)
+			}
+			cnsts, err := depSource.Constraints(context.Background(), ComposerType)
+			if err != nil {
+				t.Fatalf("unexpected error on composer constraints: %v", err)
+			}
+			if len(cnsts) == 0 {
+				t.Errorf("expected composer constraints, got none")
+			}
+		})
+	}
+}
+
+func TestGitHosts_GitHubEnterprise(t *testing.T) {
+	content, _ := json.Marshal(map[string]string{"content": string(fileMapMockData["composer.json"])})
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Host != "ghe.corp" || r.URL.Path != "/api/v3/repos/team/app/contents/composer.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(content)
+	}))
+
+	depSource, err := NewGitSource(cl, "git@ghe.corp:team/app.git", "", WithGitHubEnterpriseHost("ghe.corp", "", ""))
+	if err != nil {
+		t.Fatalf("unexpected error on new github enterprise source: %v", err)
+	}
+	cnsts, err := depSource.Constraints(context.Background(), ComposerType)
+	if err != nil {
+		t.Fatalf("unexpected error on composer constraints: %v", err)
+	}
+	if len(cnsts) == 0 {
+		t.Errorf("expected composer constraints, got none")
+	}
+
+	depSource, err = NewGitSource(cl, "git@ghe.corp:team/app.git", "", WithGitHubEnterpriseHost("ghe.corp", "://ghe.corp", ""))
+	if err == nil || depSource != nil {
+		t.Errorf("expected error on invalid github enterprise url, got: %+v, %v", depSource, err)
+	}
+}
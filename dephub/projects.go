@@ -0,0 +1,292 @@
+package dephub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+	"github.com/dephub/dephub-core/providers/parsers"
+)
+
+// defaultManifests - default manifest files paths (relative to the project directory) of every package manager.
+//
+// Conda environment files are pip manifests only if they have nested pip dependencies (see pipManifests).
+var defaultManifests = map[DepType][]string{
+	ComposerType: {"composer.json"},
+	PIPType:      {"requirements.txt", "environment.yml"},
+	CargoType:    {"Cargo.toml"},
+	MavenType:    {"pom.xml"},
+	GradleType:   {"gradle/libs.versions.toml"},
+	CondaType:    {"environment.yml"},
+}
+
+// defaultExcludedDirs - directories skipped by default on the source scan.
+var defaultExcludedDirs = []string{"vendor", "node_modules", ".git"}
+
+// SourceOption configures dependency source (see NewMemorySource and NewGitSource).
+type SourceOption func(*sourceConfig)
+
+// WithManifestPath overrides manifest files paths of the package manager.
+//
+// Paths are relative to the project directory and may contain patterns (see fetchers.MatchPattern),
+// e.g. 'requirements/*.txt', patterns require the source to support files listing.
+func WithManifestPath(typ DepType, paths ...string) SourceOption {
+	return func(cfg *sourceConfig) {
+		if cfg.manifests == nil {
+			cfg.manifests = map[DepType][]string{}
+		}
+		cfg.manifests[typ] = paths
+	}
+}
+
+// WithProjectDirs limits directories where projects are discovered on Scan call.
+//
+// Patterns follow fetchers.MatchPattern syntax (e.g. 'services/*'), default is '**' (any directory).
+func WithProjectDirs(patterns ...string) SourceOption {
+	return func(cfg *sourceConfig) {
+		cfg.projectDirs = patterns
+	}
+}
+
+// WithExcludedDirs overrides directories names skipped on Scan call (default are 'vendor', 'node_modules' and '.git').
+func WithExcludedDirs(names ...string) SourceOption {
+	return func(cfg *sourceConfig) {
+		cfg.excludedDirs = names
+	}
+}
+
+// WithCache configures git source files cache (e.g. fetchers.NewDiskCache to share it between runs),
+// in-memory cache is used by default. Files of full commit hash revisions are never revalidated.
+func WithCache(cache fetchers.Cache) SourceOption {
+	return func(cfg *sourceConfig) {
+		cfg.cache = cache
+	}
+}
+
+// sourceConfig represents dependency source configuration, nil config means defaults.
+type sourceConfig struct {
+	manifests    map[DepType][]string
+	projectDirs  []string
+	excludedDirs []string
+	gitHosts     map[string]GitProvider
+	cache        fetchers.Cache
+}
+
+// newSourceConfig applies options to the default configuration.
+func newSourceConfig(opts []SourceOption) *sourceConfig {
+	cfg := &sourceConfig{
+		projectDirs:  []string{"**"},
+		excludedDirs: defaultExcludedDirs,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// manifestPaths returns configured manifest paths of the package manager.
+func (cfg *sourceConfig) manifestPaths(typ DepType) []string {
+	if cfg != nil {
+		if paths, ok := cfg.manifests[typ]; ok {
+			return paths
+		}
+	}
+	return defaultManifests[typ]
+}
+
+// dirs returns configured projects directories patterns.
+func (cfg *sourceConfig) dirs() []string {
+	if cfg == nil {
+		return []string{"**"}
+	}
+	return cfg.projectDirs
+}
+
+// excluded reports whether the file is stored in any of the excluded directories.
+func (cfg *sourceConfig) excluded(file string) bool {
+	names := defaultExcludedDirs
+	if cfg != nil {
+		names = cfg.excludedDirs
+	}
+	segments := strings.Split(file, "/")
+	for _, segment := range segments[:len(segments)-1] {
+		for _, name := range names {
+			if segment == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Project represents one project (directory with package managers files) found in the source.
+type Project struct {
+	// Dir is the project directory related to the source root ('.' is the root itself).
+	Dir string
+	// Types are package managers used by the project.
+	Types []DepType
+	// Manifests are found manifest files of every package manager.
+	Manifests map[DepType][]string
+	// Constraints are merged dependencies constraints of every package manager.
+	Constraints map[DepType][]Constraint
+	// Requirements are merged locked dependencies of every package manager (if any lock file present).
+	Requirements map[DepType][]Requirement
+	// Errors are package managers files parsing errors, failed package managers are skipped.
+	Errors map[DepType]error
+}
+
+// scanProjects - helper to discover and parse every project in the source.
+func scanProjects(ctx context.Context, fetcher fetchers.FileFetcher, cfg *sourceConfig) ([]Project, error) {
+	lister, ok := fetcher.(fetchers.FileLister)
+	if !ok {
+		return nil, ErrListingNotSupported
+	}
+	files, err := lister.ListFiles(ctx, "**")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list source files: %w", err)
+	}
+
+	projects := map[string]*Project{}
+	for _, file := range files {
+		if cfg.excluded(file) {
+			continue
+		}
+		for _, typ := range depTypes {
+			for _, manifest := range cfg.manifestPaths(typ) {
+				dir, ok := manifestDir(cfg, manifest, file)
+				if !ok {
+					continue
+				}
+				prj, ok := projects[dir]
+				if !ok {
+					prj = &Project{
+						Dir:          dir,
+						Manifests:    map[DepType][]string{},
+						Constraints:  map[DepType][]Constraint{},
+						Requirements: map[DepType][]Requirement{},
+						Errors:       map[DepType]error{},
+					}
+					projects[dir] = prj
+				}
+				if len(prj.Manifests[typ]) == 0 || prj.Manifests[typ][len(prj.Manifests[typ])-1] != file {
+					prj.Manifests[typ] = append(prj.Manifests[typ], file)
+				}
+			}
+		}
+	}
+
+	result := make([]Project, 0, len(projects))
+	for _, prj := range projects {
+		for _, typ := range depTypes {
+			manifests, ok := prj.Manifests[typ]
+			if !ok {
+				continue
+			}
+			if typ == PIPType {
+				var err error
+				if manifests, err = pipManifests(ctx, fetcher, manifests); err != nil {
+					prj.Types = append(prj.Types, typ)
+					prj.Errors[typ] = err
+					continue
+				}
+				if len(manifests) == 0 {
+					delete(prj.Manifests, typ)
+					continue
+				}
+				prj.Manifests[typ] = manifests
+			}
+			prj.Types = append(prj.Types, typ)
+
+			cnsts, err := parseConstraintsFiles(ctx, typ, fetcher, manifests)
+			if err != nil {
+				prj.Errors[typ] = err
+				continue
+			}
+			prj.Constraints[typ] = cnsts
+
+			reqs, err := parseRequirementsFiles(ctx, typ, fetcher, manifests)
+			if err != nil && !errors.Is(err, parsers.ErrFileNotFound) {
+				prj.Errors[typ] = err
+				continue
+			}
+			if err == nil {
+				prj.Requirements[typ] = reqs
+			}
+		}
+		result = append(result, *prj)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Dir < result[j].Dir
+	})
+
+	return result, nil
+}
+
+// manifestDir returns the project directory if the file is the configured manifest of the project.
+func manifestDir(cfg *sourceConfig, manifest, file string) (string, bool) {
+	for _, dir := range cfg.dirs() {
+		if !fetchers.MatchPattern(path.Join(dir, manifest), file) {
+			continue
+		}
+		segments := strings.Split(file, "/")
+		depth := len(strings.Split(path.Clean(manifest), "/"))
+		if depth >= len(segments) {
+			return ".", true
+		}
+		return strings.Join(segments[:len(segments)-depth], "/"), true
+	}
+	return "", false
+}
+
+// projectManifests - helper to resolve configured manifest files of the package manager in the project directory.
+func projectManifests(ctx context.Context, typ DepType, fetcher fetchers.FileFetcher, cfg *sourceConfig, dir string) ([]string, error) {
+	if _, ok := defaultManifests[typ]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, typ)
+	}
+
+	var result []string
+	for _, manifest := range cfg.manifestPaths(typ) {
+		file := path.Join(dir, manifest)
+		if !fetchers.IsPattern(file) {
+			result = append(result, file)
+			continue
+		}
+
+		lister, ok := fetcher.(fetchers.FileLister)
+		if !ok {
+			return result, fmt.Errorf("%w: unable to resolve %q manifest pattern", ErrListingNotSupported, file)
+		}
+		files, err := lister.ListFiles(ctx, file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list %q manifest files: %w", file, err)
+		}
+		result = append(result, files...)
+	}
+	if typ == PIPType {
+		return pipManifests(ctx, fetcher, result)
+	}
+	return result, nil
+}
+
+// pipManifests - helper to skip conda environment files without nested pip dependencies ('pip:' section)
+// from the pip manifest files, missing files are kept as is.
+func pipManifests(ctx context.Context, fetcher fetchers.FileFetcher, files []string) ([]string, error) {
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		if parsers.IsCondaEnvironment(file) {
+			ok, err := parsers.NewCondaParser(fetcher, file).(*parsers.CondaParser).HasPipDependencies(ctx)
+			if err != nil && err != parsers.ErrFileNotFound {
+				return nil, fmt.Errorf("unable to read %q pip dependencies: %w", file, err)
+			}
+			if err == nil && !ok {
+				continue
+			}
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
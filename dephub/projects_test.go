@@ -0,0 +1,227 @@
+package dephub
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dephub/dephub-core/providers/parsers"
+)
+
+// monorepoMockData is a monorepo with several projects of different package managers.
+var monorepoMockData = map[string][]byte{
+	"services/billing/composer.json":                      []byte(`{"require": {"php": "^8.0", "monolog/monolog": "^2.3"}}`),
+	"services/billing/composer.lock":                      []byte(`{"packages": [{"name": "monolog/monolog", "version": "2.3.5"}]}`),
+	"services/auth/composer.json":                         []byte(`{"require": {"firebase/php-jwt": "^5.5"}}`),
+	"services/auth/vendor/firebase/php-jwt/composer.json": []byte(`{"require": {"php": ">=5.3.0"}}`),
+	"apps/api/requirements/base.txt":                      []byte("Django==3.2.9\nrequests>=2.26"),
+	"apps/api/requirements/dev.txt":                       []byte("pytest==6.2.5\nrequests>=2.26"),
+	"apps/web/requirements.txt":                           []byte("Flask==2.0.2"),
+	"node_modules/pkg/requirements.txt":                   []byte("six==1.16.0"),
+	"composer.json":                                       []byte(`{"require": {"phpunit/phpunit": "^9.5"}}`),
+	"README.md":                                           []byte("# Monorepo"),
+}
+
+func TestDependencySource_ScanMethod(t *testing.T) {
+	depSource := NewMemorySource(monorepoMockData, WithManifestPath(PIPType, "requirements.txt", "requirements/*.txt"))
+	projects, err := depSource.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on memory source scan: %v", err)
+	}
+
+	dirs := []string{}
+	for _, prj := range projects {
+		dirs = append(dirs, prj.Dir)
+		if len(prj.Errors) != 0 {
+			t.Errorf("unexpected %q project errors: %+v", prj.Dir, prj.Errors)
+		}
+	}
+	if !reflect.DeepEqual(dirs, []string{".", "apps/api", "apps/web", "services/auth", "services/billing"}) {
+		t.Fatalf("unexpected scanned projects: %+v", dirs)
+	}
+
+	api := projects[1]
+	if !reflect.DeepEqual(api.Types, []DepType{PIPType}) {
+		t.Errorf("unexpected 'apps/api' project types: %+v", api.Types)
+	}
+	if !reflect.DeepEqual(api.Manifests[PIPType], []string{"apps/api/requirements/base.txt", "apps/api/requirements/dev.txt"}) {
+		t.Errorf("unexpected 'apps/api' project manifests: %+v", api.Manifests)
+	}
+	cnsts := api.Constraints[PIPType]
+	sort.Slice(cnsts, func(i, j int) bool {
+		return cnsts[i].Name > cnsts[j].Name
+	})
+	expCnsts := []Constraint{{"requests", ">=2.26"}, {"pytest", "==6.2.5"}, {"Django", "==3.2.9"}}
+	if !reflect.DeepEqual(cnsts, expCnsts) {
+		t.Errorf("unexpected 'apps/api' project constraints: %+v", cnsts)
+	}
+
+	billing := projects[4]
+	if !reflect.DeepEqual(billing.Requirements[ComposerType], []Requirement{{"monolog/monolog", "2.3.5", true}}) {
+		t.Errorf("unexpected 'services/billing' project requirements: %+v", billing.Requirements)
+	}
+	// Missing lock file is not an error
+	if _, ok := projects[3].Requirements[ComposerType]; ok {
+		t.Errorf("expected no requirements without lock file, got: %+v", projects[3].Requirements)
+	}
+}
+
+func TestDependencySource_ScanProjectDirs(t *testing.T) {
+	depSource := NewMemorySource(monorepoMockData, WithProjectDirs("services/*"), WithExcludedDirs())
+	projects, err := depSource.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on memory source scan: %v", err)
+	}
+
+	dirs := []string{}
+	for _, prj := range projects {
+		dirs = append(dirs, prj.Dir)
+	}
+	if !reflect.DeepEqual(dirs, []string{"services/auth", "services/billing"}) {
+		t.Errorf("unexpected scanned projects: %+v", dirs)
+	}
+
+	gitDepSource := GitDependencySource{fetcher: failingFetcher{err: errors.New("rate limit exceeded")}}
+	projects, err = gitDepSource.Scan(context.Background())
+	if !errors.Is(err, ErrListingNotSupported) {
+		t.Errorf("expected listing not supported error, got: %v", err)
+	}
+	if projects != nil {
+		t.Errorf("expected nil projects on scan error, got: %+v", projects)
+	}
+}
+
+func TestDependencySource_ManifestPath(t *testing.T) {
+	depSource := NewMemorySource(monorepoMockData,
+		WithManifestPath(PIPType, "apps/api/requirements/*.txt", "apps/web/requirements.txt"),
+		WithManifestPath(ComposerType, "services/billing/composer.json"),
+	)
+
+	pipCnsts, err := depSource.Constraints(context.Background(), PIPType)
+	if err != nil {
+		t.Fatalf("unexpected error on pip memory source constraints: %v", err)
+	}
+	if len(pipCnsts) != 4 {
+		t.Errorf("expected 4 merged pip constraints, got: %+v", pipCnsts)
+	}
+
+	composerReqs, err := depSource.Requirements(context.Background(), ComposerType)
+	if err != nil {
+		t.Fatalf("unexpected error on composer memory source requirements: %v", err)
+	}
+	if !reflect.DeepEqual(composerReqs, []Requirement{{"monolog/monolog", "2.3.5", true}}) {
+		t.Errorf("unexpected composer requirements: %+v", composerReqs)
+	}
+
+	types, err := depSource.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on memory source detection: %v", err)
+	}
+	if !reflect.DeepEqual(types, []DepType{ComposerType, PIPType}) {
+		t.Errorf("unexpected detected types from mem source: %+v", types)
+	}
+
+	gitDepSource := GitDependencySource{
+		fetcher: failingFetcher{err: errors.New("rate limit exceeded")},
+		cfg:     newSourceConfig([]SourceOption{WithManifestPath(PIPType, "requirements/*.txt")}),
+	}
+	_, err = gitDepSource.Constraints(context.Background(), PIPType)
+	if !errors.Is(err, ErrListingNotSupported) {
+		t.Errorf("expected listing not supported error on manifest pattern, got: %v", err)
+	}
+}
+
+func TestDependencySource_PipCanonicalNames(t *testing.T) {
+	depSource := NewMemorySource(map[string][]byte{
+		"requirements/base.txt": []byte("Django==3.2.9\npython_dateutil>=2.8"),
+		"requirements/dev.txt":  []byte("django==3.2.10\nPython-DateUtil>=2.8\npytest==6.2.5"),
+		"composer.json":         []byte(`{"require": {"monolog/monolog": "^2.0", "Monolog/Monolog": "^2.0"}}`),
+	}, WithManifestPath(PIPType, "requirements/*.txt"))
+
+	pipCnsts, err := depSource.Constraints(context.Background(), PIPType)
+	if err != nil {
+		t.Fatalf("unexpected error on pip memory source constraints: %v", err)
+	}
+	// The first spelling is kept, the last constraint wins
+	expected := []Constraint{{"Django", "==3.2.10"}, {"python_dateutil", ">=2.8"}, {"pytest", "==6.2.5"}}
+	if !reflect.DeepEqual(pipCnsts, expected) {
+		t.Errorf("unexpected merged pip constraints: %+v", pipCnsts)
+	}
+
+	// Other package managers names are kept as is
+	composerCnsts, err := depSource.Constraints(context.Background(), ComposerType)
+	if err != nil {
+		t.Fatalf("unexpected error on composer memory source constraints: %v", err)
+	}
+	if len(composerCnsts) != 2 {
+		t.Errorf("expected 2 composer constraints, got: %+v", composerCnsts)
+	}
+}
+
+// condaPipEnvironment is conda environment file with nested pip dependencies.
+var condaPipEnvironment = []byte(`
+channels:
+  - conda-forge
+dependencies:
+  - numpy=1.21
+  - pip
+  - pip:
+    - requests==2.26.0
+`)
+
+func TestDependencySource_CondaPipDependencies(t *testing.T) {
+	depSource := NewMemorySource(map[string][]byte{
+		"ml/environment.yml":   condaPipEnvironment,
+		"data/environment.yml": []byte("dependencies:\n  - pandas>=1.3"),
+	})
+	projects, err := depSource.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on memory source scan: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got: %+v", projects)
+	}
+
+	// Environment without 'pip:' section is not the pip manifest
+	if data := projects[0]; !reflect.DeepEqual(data.Types, []DepType{CondaType}) {
+		t.Errorf("unexpected 'data' project types: %+v", data.Types)
+	}
+	ml := projects[1]
+	if !reflect.DeepEqual(ml.Types, []DepType{PIPType, CondaType}) {
+		t.Errorf("unexpected 'ml' project types: %+v", ml.Types)
+	}
+	if len(ml.Errors) != 0 {
+		t.Errorf("unexpected 'ml' project errors: %+v", ml.Errors)
+	}
+	expected := map[DepType][]Constraint{
+		PIPType:   {{"requests", "==2.26.0"}},
+		CondaType: {{"conda-forge::numpy", "=1.21"}, {"conda-forge::pip", "*"}},
+	}
+	if !reflect.DeepEqual(ml.Constraints, expected) {
+		t.Errorf("unexpected 'ml' project constraints: %+v", ml.Constraints)
+	}
+
+	depSource = NewMemorySource(map[string][]byte{"environment.yml": condaPipEnvironment})
+	types, err := depSource.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on memory source detection: %v", err)
+	}
+	if !reflect.DeepEqual(types, []DepType{PIPType, CondaType}) {
+		t.Errorf("unexpected detected types from mem source: %+v", types)
+	}
+	pipCnsts, err := depSource.Constraints(context.Background(), PIPType)
+	if err != nil || !reflect.DeepEqual(pipCnsts, expected[PIPType]) {
+		t.Errorf("unexpected pip constraints: %+v, %v", pipCnsts, err)
+	}
+
+	depSource = NewMemorySource(map[string][]byte{"environment.yml": []byte("dependencies:\n  - pandas>=1.3")})
+	types, err = depSource.Detect(context.Background())
+	if err != nil || !reflect.DeepEqual(types, []DepType{CondaType}) {
+		t.Errorf("unexpected detected types from mem source: %+v, %v", types, err)
+	}
+	if _, err = depSource.Constraints(context.Background(), PIPType); err != parsers.ErrFileNotFound {
+		t.Errorf("expected file not found error on pip constraints, got: %v", err)
+	}
+}
@@ -8,16 +8,28 @@ package dephub
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"regexp"
 	"strings"
 
 	"github.com/dephub/dephub-core/providers/fetchers"
 	"github.com/dephub/dephub-core/providers/parsers"
+	"github.com/dephub/dephub-core/providers/transport"
+	"github.com/dephub/dephub-core/providers/versioneer"
 )
 
-// todo: add rate limiting error variable
+var (
+	// ErrRateLimited is returned (wrapped) when the source or registry rate limit is exceeded,
+	// use fetchers.RateLimitReset to get the reset time.
+	ErrRateLimited = transport.ErrRateLimited
+	// ErrUnsupportedType is returned when the package manager type is unknown.
+	ErrUnsupportedType = errors.New("unsupported package manager type")
+	// ErrListingNotSupported is returned when the source is unable to list it's files (e.g. on Scan call).
+	ErrListingNotSupported = errors.New("source doesn't support files listing")
+)
 
 // DepType represents package manager type flag.
 type DepType string
@@ -28,8 +40,25 @@ const (
 	ComposerType = DepType("composer")
 	// PIPType represents Python's PIP package manager flag.
 	PIPType = DepType("pip")
+	// CargoType represents Rust's Cargo package manager flag.
+	CargoType = DepType("cargo")
+	// MavenType represents Java's Maven package manager flag.
+	MavenType = DepType("maven")
+	// GradleType represents Gradle version catalog flag (dependencies are served by maven repositories).
+	GradleType = DepType("gradle")
+	// CondaType represents conda package manager flag (environment.yml).
+	CondaType = DepType("conda")
 )
 
+// depTypes - supported package managers (in the detection order).
+var depTypes = []DepType{ComposerType, PIPType, CargoType, MavenType, GradleType, CondaType}
+
+// depTypeLockFiles - lock files names (stored next to the manifests), used for the detection.
+var depTypeLockFiles = map[DepType]string{
+	ComposerType: "composer.lock",
+	CargoType:    "Cargo.lock",
+}
+
 // Constraint represents one dependency/constraint.
 type Constraint struct {
 	Name    string
@@ -58,6 +87,11 @@ var gitRepoRgx string = `^(((git@)|(git:|ssh:|(http[s]?:\/\/))))([\w\.@\\-~]+)(:
 // gitRepoRgxCompiled is compiled from gitRepoRgx.
 var gitRepoRgxCompiled *regexp.Regexp
 
+// gitURLRgx matches git URLs with the explicit user or port (e.g. 'ssh://git@myhostname:7999/vendor/reponame.git'):
+//     1: hostname
+//     2: full repo name with '.git' suffix
+var gitURLRgx = regexp.MustCompile(`^(?:ssh|https?):\/\/(?:[^@\/]+@)?([^:@\/]+)(?::\d+)?\/(.+)$`)
+
 func init() {
 	gitRepoRgxCompiled = regexp.MustCompile(gitRepoRgx)
 }
@@ -69,40 +103,115 @@ type DependencySource interface {
 	Requirements(ctx context.Context, typ DepType) ([]Requirement, error)
 	// Constraints returns list of project's dependencies constraints.
 	Constraints(ctx context.Context, typ DepType) ([]Constraint, error)
+	// Detect returns list of package managers which files are present in the source.
+	Detect(ctx context.Context) ([]DepType, error)
+	// Scan discovers every project (directory with package managers files) in the source tree.
+	Scan(ctx context.Context) ([]Project, error)
 }
 
-func NewMemorySource(files map[string][]byte) DependencySource {
+// NewMemorySource constructs in-memory DependencySource implementation ('path:content' map of the files).
+func NewMemorySource(files map[string][]byte, opts ...SourceOption) DependencySource {
 	return &MemoryDependencySource{
-		fetchers.ByteMapFetcher{Files: files},
+		fetcher: fetchers.ByteMapFetcher{Files: files},
+		cfg:     newSourceConfig(opts),
 	}
 }
 
+// MemoryDependencySource represents in-memory DependencySource implementation.
 type MemoryDependencySource struct {
 	fetcher fetchers.ByteMapFetcher
+	cfg     *sourceConfig
 }
 
 // Requirements returns list of project's locked dependencies versions (if any).
 //
 // Return value is a 'pkg_name:version' map.
 func (ldds MemoryDependencySource) Requirements(ctx context.Context, typ DepType) ([]Requirement, error) {
-	return parseRequirements(ctx, typ, ldds.fetcher)
+	return parseRequirements(ctx, typ, ldds.fetcher, ldds.cfg, ".")
 }
 
 // Constraints returns list of project's dependencies constraints.
 //
 // Return value is a 'pkg_name:constraint' map.
 func (ldds MemoryDependencySource) Constraints(ctx context.Context, typ DepType) ([]Constraint, error) {
-	return parseConstraints(ctx, typ, ldds.fetcher)
+	return parseConstraints(ctx, typ, ldds.fetcher, ldds.cfg, ".")
+}
+
+// Detect returns list of package managers which files are present in the source.
+func (ldds MemoryDependencySource) Detect(ctx context.Context) ([]DepType, error) {
+	return detectTypes(ctx, ldds.fetcher, ldds.cfg)
+}
+
+// Scan discovers every project (directory with package managers files) in the source tree.
+func (ldds MemoryDependencySource) Scan(ctx context.Context) ([]Project, error) {
+	return scanProjects(ctx, ldds.fetcher, ldds.cfg)
+}
+
+// files returns the source files fetcher and configuration.
+func (ldds MemoryDependencySource) files() (fetchers.FileFetcher, *sourceConfig) {
+	return ldds.fetcher, ldds.cfg
+}
+
+// NewLocalSource constructs local directory DependencySource implementation (e.g. repository working copy in CI).
+//
+// Files are read with fetchers.NewLocalFetcher defaults: symbolic links are followed within the directory
+// and files size is limited by fetchers.DefaultMaxFileSize.
+func NewLocalSource(dir string, opts ...SourceOption) (DependencySource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open local source directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local source %q is not a directory", dir)
+	}
+	return &LocalDependencySource{
+		fetcher: fetchers.NewLocalFetcher(dir),
+		cfg:     newSourceConfig(opts),
+	}, nil
+}
+
+// LocalDependencySource represents local directory DependencySource implementation.
+type LocalDependencySource struct {
+	fetcher fetchers.FileFetcher
+	cfg     *sourceConfig
+}
+
+// Requirements returns list of project's locked dependencies versions (if any).
+//
+// Return value is a 'pkg_name:version' map.
+func (lds LocalDependencySource) Requirements(ctx context.Context, typ DepType) ([]Requirement, error) {
+	return parseRequirements(ctx, typ, lds.fetcher, lds.cfg, ".")
+}
+
+// Constraints returns list of project's dependencies constraints.
+//
+// Return value is a 'pkg_name:constraint' map.
+func (lds LocalDependencySource) Constraints(ctx context.Context, typ DepType) ([]Constraint, error) {
+	return parseConstraints(ctx, typ, lds.fetcher, lds.cfg, ".")
+}
+
+// Detect returns list of package managers which files are present in the source.
+func (lds LocalDependencySource) Detect(ctx context.Context) ([]DepType, error) {
+	return detectTypes(ctx, lds.fetcher, lds.cfg)
+}
+
+// Scan discovers every project (directory with package managers files) in the source tree.
+func (lds LocalDependencySource) Scan(ctx context.Context) ([]Project, error) {
+	return scanProjects(ctx, lds.fetcher, lds.cfg)
+}
+
+// files returns the source files fetcher and configuration.
+func (lds LocalDependencySource) files() (fetchers.FileFetcher, *sourceConfig) {
+	return lds.fetcher, lds.cfg
 }
 
 // gitRepo represents basic repository information.
 type gitRepo struct {
 	host, vendor, repo string
+	// path is the full repository path (e.g. 'group/subgroup/repo' for GitLab nested groups)
+	path string
 }
 
-// supGitSrcs - supported git sources.
-var supGitSrcs = []string{"github.com"}
-
 // NewGitSource constructs new Git DependencySource implementation.
 //
 // SHA can both refer to commit hash/branch/tag.
@@ -111,17 +220,29 @@ var supGitSrcs = []string{"github.com"}
 // for example you would like to pass OAuth2/BasicAuth information to github API for increased
 // rate limits and so on.
 //
-// repoAddr is your repository address (e.g. 'git@myhostname:vendor/reponame.git')
-func NewGitSource(httpClient *http.Client, repoAddr, sha string) (DependencySource, error) {
-	repoData, err := parseGitAddr(repoAddr)
+// repoAddr is your repository address (e.g. 'git@myhostname:vendor/reponame.git'),
+// github.com, gitlab.com (including nested groups, e.g. 'git@gitlab.com:group/subgroup/repo.git'), bitbucket.org
+// and codeberg.org hosts are supported, other hosts (e.g. GitHub Enterprise Server) can be added with
+// RegisterGitHost or WithGitHost option.
+//
+// Options can be used to configure manifests paths, projects discovery and git hosts (see SourceOption).
+func NewGitSource(httpClient *http.Client, repoAddr, sha string, opts ...SourceOption) (DependencySource, error) {
+	cfg := newSourceConfig(opts)
+	repoData, err := parseGitAddr(repoAddr, cfg)
 	if err != nil {
 		return nil, err
 	}
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		httpClient = transport.DefaultClient
+	}
+
+	provider, _ := cfg.gitProvider(repoData.host)
+	fetcher, err := provider(httpClient, repoData.path, sha)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %q git source fetcher: %w", repoData.host, err)
 	}
-	fetcher := fetchers.NewGitHubFetcher(httpClient, repoData.vendor, repoData.repo, sha)
-	return &GitDependencySource{fetcher: fetcher}, nil
+	// Files are cached at least for the source lifetime (e.g. composer.json is used both for constraints and requirements)
+	return &GitDependencySource{fetcher: fetchers.NewCachingFetcher(fetcher, cfg.cache), cfg: cfg}, nil
 }
 
 // GitDependencySource represents Git DependencySource implementation,
@@ -129,69 +250,249 @@ func NewGitSource(httpClient *http.Client, repoAddr, sha string) (DependencySour
 // managers specific information from them.
 type GitDependencySource struct {
 	fetcher fetchers.FileFetcher
+	cfg     *sourceConfig
 }
 
 // Requirements returns list of project's locked dependencies versions (if any).
 //
 // Return value is a 'pkg_name:version' map.
 func (gds GitDependencySource) Requirements(ctx context.Context, typ DepType) ([]Requirement, error) {
-	return parseRequirements(ctx, typ, gds.fetcher)
+	return parseRequirements(ctx, typ, gds.fetcher, gds.cfg, ".")
 }
 
 // Constraints returns list of project's dependencies constraints.
 //
 // Return value is a 'pkg_name:constraint' map.
 func (gds GitDependencySource) Constraints(ctx context.Context, typ DepType) ([]Constraint, error) {
-	return parseConstraints(ctx, typ, gds.fetcher)
+	return parseConstraints(ctx, typ, gds.fetcher, gds.cfg, ".")
+}
+
+// Detect returns list of package managers which files are present in the source.
+//
+// Every known manifest and lock file is probed, so it costs several requests to the git hosting.
+func (gds GitDependencySource) Detect(ctx context.Context) ([]DepType, error) {
+	return detectTypes(ctx, gds.fetcher, gds.cfg)
+}
+
+// Scan discovers every project (directory with package managers files) in the source tree.
+//
+// The fetcher must be able to list repository files, otherwise ErrListingNotSupported is returned.
+func (gds GitDependencySource) Scan(ctx context.Context) ([]Project, error) {
+	return scanProjects(ctx, gds.fetcher, gds.cfg)
+}
+
+// files returns the source files fetcher and configuration.
+func (gds GitDependencySource) files() (fetchers.FileFetcher, *sourceConfig) {
+	return gds.fetcher, gds.cfg
+}
+
+// fileSource is implemented by the sources reading package managers files with the fetcher.
+type fileSource interface {
+	files() (fetchers.FileFetcher, *sourceConfig)
 }
 
-func parseRequirements(ctx context.Context, typ DepType, fetcher fetchers.FileFetcher) ([]Requirement, error) {
-	csts, err := solveParser(typ, fetcher).Requirements(ctx)
+// parseRequirements - helper to get locked dependencies of the package manager in the project directory.
+//
+// Requirements of all the project manifests (e.g. 'requirements/*.txt') are merged.
+func parseRequirements(ctx context.Context, typ DepType, fetcher fetchers.FileFetcher, cfg *sourceConfig, dir string) ([]Requirement, error) {
+	files, err := projectManifests(ctx, typ, fetcher, cfg, dir)
 	if err != nil {
 		return nil, err
 	}
-	result := []Requirement{}
-	for _, cst := range csts {
-		result = append(result, Requirement(cst))
+	return parseRequirementsFiles(ctx, typ, fetcher, files)
+}
+
+// parseRequirementsFiles - helper to get merged locked dependencies of the package manager manifests files.
+func parseRequirementsFiles(ctx context.Context, typ DepType, fetcher fetchers.FileFetcher, files []string) ([]Requirement, error) {
+	var result []Requirement
+	for _, file := range files {
+		parser, err := solveParser(typ, fetcher, file)
+		if err != nil {
+			return nil, err
+		}
+		csts, err := parser.Requirements(ctx)
+		if err == parsers.ErrFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = []Requirement{}
+		}
+		for _, cst := range csts {
+			result = append(result, Requirement(cst))
+		}
+	}
+	if result == nil {
+		return nil, parsers.ErrFileNotFound
 	}
 	return result, nil
 }
 
-func parseConstraints(ctx context.Context, typ DepType, fetcher fetchers.FileFetcher) ([]Constraint, error) {
-	csts, err := solveParser(typ, fetcher).Constraints(ctx)
+// parseConstraints - helper to get dependencies constraints of the package manager in the project directory.
+//
+// Constraints of all the project manifests (e.g. 'requirements/*.txt') are merged, duplicates are skipped
+// (pip packages constraints are merged by the canonical names, the last constraint wins).
+func parseConstraints(ctx context.Context, typ DepType, fetcher fetchers.FileFetcher, cfg *sourceConfig, dir string) ([]Constraint, error) {
+	files, err := projectManifests(ctx, typ, fetcher, cfg, dir)
 	if err != nil {
 		return nil, err
 	}
-	result := []Constraint{}
-	for _, cst := range csts {
-		result = append(result, Constraint(cst))
+	return parseConstraintsFiles(ctx, typ, fetcher, files)
+}
+
+// parseConstraintsFiles - helper to get merged dependencies constraints of the package manager manifests files.
+func parseConstraintsFiles(ctx context.Context, typ DepType, fetcher fetchers.FileFetcher, files []string) ([]Constraint, error) {
+	var result []Constraint
+	seen := map[Constraint]int{}
+	for _, file := range files {
+		parser, err := solveParser(typ, fetcher, file)
+		if err != nil {
+			return nil, err
+		}
+		csts, err := parser.Constraints(ctx)
+		if err == parsers.ErrFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = []Constraint{}
+		}
+		for _, cst := range csts {
+			key := constraintKey(typ, Constraint(cst))
+			if i, ok := seen[key]; ok {
+				result[i].Version = cst.Version
+				continue
+			}
+			seen[key] = len(result)
+			result = append(result, Constraint(cst))
+		}
+	}
+	if result == nil {
+		return nil, parsers.ErrFileNotFound
 	}
 	return result, nil
 }
 
-// solveParser - helper to get configured package manager files parser
+// constraintKey - helper to get the constraint duplicates lookup key. Pip packages are matched by their canonical
+// names (PEP 503) only, like in a single requirements file the first spelling is kept and the last constraint wins.
+func constraintKey(typ DepType, cst Constraint) Constraint {
+	if typ == PIPType {
+		return Constraint{Name: versioneer.NormalizePipName(cst.Name).String()}
+	}
+	return cst
+}
+
+// detectTypes - helper to find package managers files in the source root.
 //
-// todo: changable filepaths for parsers
-func solveParser(typ DepType, fetcher fetchers.FileFetcher) parsers.DependencyParser {
+// Sources capable of listing files are listed once, other ones are probed file by file.
+func detectTypes(ctx context.Context, fetcher fetchers.FileFetcher, cfg *sourceConfig) ([]DepType, error) {
+	if lister, ok := fetcher.(fetchers.FileLister); ok {
+		return detectListedTypes(ctx, lister, fetcher, cfg)
+	}
+
+	result := []DepType{}
+	for _, typ := range depTypes {
+		files, err := projectManifests(ctx, typ, fetcher, cfg, ".")
+		if err != nil && !errors.Is(err, ErrListingNotSupported) {
+			return nil, fmt.Errorf("unable to detect %q package manager files: %w", typ, err)
+		}
+		if lock, ok := depTypeLockFiles[typ]; ok {
+			files = append(files, lock)
+		}
+
+		for _, file := range files {
+			if fetchers.IsPattern(file) {
+				continue
+			}
+			_, err := fetcher.FileContent(ctx, file)
+			if err == fetchers.ErrFileNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unable to detect %q package manager files: %w", typ, err)
+			}
+			result = append(result, typ)
+			break
+		}
+	}
+	return result, nil
+}
+
+// detectListedTypes - helper to find package managers files in the listed source files.
+func detectListedTypes(ctx context.Context, lister fetchers.FileLister, fetcher fetchers.FileFetcher, cfg *sourceConfig) ([]DepType, error) {
+	files, err := lister.ListFiles(ctx, "**")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list source files: %w", err)
+	}
+
+	result := []DepType{}
+	for _, typ := range depTypes {
+		patterns := cfg.manifestPaths(typ)
+		if lock, ok := depTypeLockFiles[typ]; ok {
+			patterns = append(patterns[:len(patterns):len(patterns)], lock)
+		}
+	files:
+		for _, file := range files {
+			for _, pattern := range patterns {
+				if !fetchers.MatchPattern(pattern, file) {
+					continue
+				}
+				if typ == PIPType {
+					manifests, err := pipManifests(ctx, fetcher, []string{file})
+					if err != nil {
+						return nil, fmt.Errorf("unable to detect %q package manager files: %w", typ, err)
+					}
+					if len(manifests) == 0 {
+						break
+					}
+				}
+				result = append(result, typ)
+				break files
+			}
+		}
+	}
+	return result, nil
+}
+
+// solveParser - helper to get configured package manager files parser
+func solveParser(typ DepType, fetcher fetchers.FileFetcher, filename string) (parsers.DependencyParser, error) {
 	var parser parsers.DependencyParser
 	switch typ {
 	case ComposerType:
-		parser = parsers.NewComposerParser(fetcher)
+		parser = parsers.NewComposerFileParser(fetcher, filename)
 	case PIPType:
-		parser = parsers.NewPipParser(fetcher, "")
+		parser = parsers.NewPipParser(fetcher, filename)
+	case CargoType:
+		parser = parsers.NewCargoParser(fetcher, filename)
+	case MavenType:
+		parser = parsers.NewMavenParser(fetcher, filename)
+	case GradleType:
+		parser = parsers.NewGradleParser(fetcher, filename)
+	case CondaType:
+		parser = parsers.NewCondaParser(fetcher, filename)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, typ)
 	}
-	return parser
+	return parser, nil
 }
 
 // parserGitAddr - helper to parse information from git repository address string
-func parseGitAddr(addr string) (*gitRepo, error) {
+func parseGitAddr(addr string, cfg *sourceConfig) (*gitRepo, error) {
+	// Normalize URLs with the user and port to the scp-like form, the port is not used by the providers
+	if m := gitURLRgx.FindStringSubmatch(addr); m != nil {
+		addr = "git@" + m[1] + ":" + m[2]
+	}
 	matches := gitRepoRgxCompiled.FindStringSubmatch(addr)
 	if matches == nil || matches[6] == "" || matches[8] == "" {
 		return nil, fmt.Errorf("unsupported git repository format %q", addr)
 	}
 	hostName, repoName := matches[6], matches[8]
 
-	if !gitHostSupported(hostName) {
+	if !gitHostSupported(hostName, cfg) {
 		return nil, fmt.Errorf("git source %q is not supported", hostName)
 	}
 
@@ -200,15 +501,11 @@ func parseGitAddr(addr string) (*gitRepo, error) {
 	}
 	repoNameParts := strings.Split(repoName, "/")
 
-	return &gitRepo{host: hostName, vendor: repoNameParts[0], repo: repoNameParts[1]}, nil
+	return &gitRepo{host: hostName, vendor: repoNameParts[0], repo: repoNameParts[1], path: repoName}, nil
 }
 
 // gitHostSupported - helper to check git source support status
-func gitHostSupported(host string) bool {
-	for _, v := range supGitSrcs {
-		if v == host {
-			return true
-		}
-	}
-	return false
+func gitHostSupported(host string, cfg *sourceConfig) bool {
+	_, ok := cfg.gitProvider(host)
+	return ok
 }
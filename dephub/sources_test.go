@@ -3,11 +3,17 @@ package dephub
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/dephub/dephub-core/providers/fetchers"
@@ -153,6 +159,60 @@ func TestMemoryDependencySource_SourceErrors(t *testing.T) {
 	}
 }
 
+func TestLocalDependencySource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dephub-source")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for name, content := range fileMapMockData {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatalf("unable to write %q file: %v", name, err)
+		}
+	}
+
+	localSource, err := NewLocalSource(dir)
+	if err != nil {
+		t.Fatalf("unexpected error on local source construction: %v", err)
+	}
+	memSource := NewMemorySource(fileMapMockData)
+
+	localCnsts, err := localSource.Constraints(context.Background(), ComposerType)
+	if err != nil {
+		t.Fatalf("unexpected error on composer local source constraints: %v", err)
+	}
+	memCnsts, _ := memSource.Constraints(context.Background(), ComposerType)
+	sort.Slice(localCnsts, func(i, j int) bool { return localCnsts[i].Name > localCnsts[j].Name })
+	sort.Slice(memCnsts, func(i, j int) bool { return memCnsts[i].Name > memCnsts[j].Name })
+	if !reflect.DeepEqual(localCnsts, memCnsts) {
+		t.Errorf("unexpected composer constraints from local source: %+v", localCnsts)
+	}
+
+	localTypes, err := localSource.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on local source detection: %v", err)
+	}
+	memTypes, _ := memSource.Detect(context.Background())
+	if !reflect.DeepEqual(localTypes, memTypes) {
+		t.Errorf("unexpected detected types from local source: %+v", localTypes)
+	}
+
+	projects, err := localSource.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on local source scan: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Dir != "." {
+		t.Errorf("unexpected scanned projects from local source: %+v", projects)
+	}
+
+	if _, err := NewLocalSource(filepath.Join(dir, "composer.json")); err == nil {
+		t.Errorf("expected error on local source file path, got none")
+	}
+	if _, err := NewLocalSource(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("expected error on missing local source directory, got none")
+	}
+}
+
 func TestGitDependencySource_Constructor(t *testing.T) {
 	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		t.Fatalf("unexpected call to server on git source construction")
@@ -197,6 +257,107 @@ func TestGitDependencySource_Constructor_AddrErrors(t *testing.T) {
 	}
 }
 
+func TestGitDependencySource_GitLab(t *testing.T) {
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Host + r.URL.RequestURI() {
+		case "gitlab.example.com/api/v4/projects/group%2Fsub%2Frepo/repository/files/composer.json/raw?ref=main":
+			if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = rw.Write(fileMapMockData["composer.json"])
+		case "gitlab.com/api/v4/projects/vendor%2Frepo/repository/files/requirements.txt/raw?ref=HEAD":
+			_, _ = rw.Write(fileMapMockData["requirements.txt"])
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	depSource, err := NewGitSource(cl, "git@gitlab.example.com:group/sub/repo.git", "main", WithGitLabHost("gitlab.example.com", nil, "secret"))
+	if err != nil {
+		t.Fatalf("unexpected error on new gitlab source: %v", err)
+	}
+	composerCnsts, err := depSource.Constraints(context.Background(), ComposerType)
+	if err != nil {
+		t.Fatalf("unexpected error on composer gitlab source constraints: %v", err)
+	}
+	if len(composerCnsts) == 0 {
+		t.Errorf("expected composer constraints from gitlab source, got none")
+	}
+
+	depSource, err = NewGitSource(cl, "https://gitlab.com/vendor/repo.git", "")
+	if err != nil {
+		t.Fatalf("unexpected error on new gitlab source: %v", err)
+	}
+	pipCnsts, err := depSource.Constraints(context.Background(), PIPType)
+	if err != nil {
+		t.Fatalf("unexpected error on pip gitlab source constraints: %v", err)
+	}
+	if len(pipCnsts) == 0 {
+		t.Errorf("expected pip constraints from gitlab source, got none")
+	}
+
+	_, err = NewGitSource(cl, "git@gitlab.example.com:group/sub/repo.git", "main")
+	if err == nil || err.Error() != `git source "gitlab.example.com" is not supported` {
+		t.Errorf("expected unsupported git source error without gitlab host option, got: %v", err)
+	}
+}
+
+func TestGitDependencySource_Cache(t *testing.T) {
+	requests := map[string]int{}
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		name := strings.TrimPrefix(r.URL.Path, "/repos/hello/world/contents/")
+		content, ok := fileMapMockData[name]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := json.Marshal(map[string]string{"content": string(content)})
+		_, _ = rw.Write(b)
+	}))
+
+	depSource, err := NewGitSource(cl, "git@github.com/hello/world.git", "", WithCache(fetchers.NewMemoryCache()))
+	if err != nil {
+		t.Fatalf("unexpected error on new git source: %v", err)
+	}
+	if _, err := depSource.Constraints(context.Background(), ComposerType); err != nil {
+		t.Fatalf("unexpected error on composer constraints: %v", err)
+	}
+	if _, err := depSource.Requirements(context.Background(), ComposerType); err != nil {
+		t.Fatalf("unexpected error on composer requirements: %v", err)
+	}
+	if requests["/repos/hello/world/contents/composer.json"] == 0 {
+		t.Fatalf("expected composer.json to be fetched, got requests: %+v", requests)
+	}
+	for path, count := range requests {
+		if count != 1 {
+			t.Errorf("expected %q file to be fetched once, got %d requests", path, count)
+		}
+	}
+}
+
+func TestGitDependencySource_FetchErrors(t *testing.T) {
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		_, _ = rw.Write([]byte(`{"message": "Bad credentials"}`))
+	}))
+
+	depSource, err := NewGitSource(cl, "git@github.com/hello/world.git", "")
+	if err != nil {
+		t.Fatalf("unexpected error on new git source: %v", err)
+	}
+	if _, err = depSource.Constraints(context.Background(), ComposerType); !errors.Is(err, fetchers.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error on constraints, got: %v", err)
+	}
+	if _, err = depSource.Requirements(context.Background(), CargoType); !errors.Is(err, fetchers.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error on requirements, got: %v", err)
+	}
+	if _, err = depSource.Detect(context.Background()); !errors.Is(err, fetchers.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error on detection, got: %v", err)
+	}
+}
+
 func TestGitDependencySource_Methods(t *testing.T) {
 	gitDepSource := GitDependencySource{fetcher: fetchers.ByteMapFetcher{Files: fileMapMockData}}
 
@@ -262,3 +423,94 @@ func TestGitDependencySource_Methods(t *testing.T) {
 		t.Errorf("expected empty result from pip requirements from mem source, got: %+v", pipReqs)
 	}
 }
+
+// failingFetcher always fails with the configured error.
+type failingFetcher struct {
+	err error
+}
+
+func (ff failingFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
+	return nil, ff.err
+}
+
+func TestDependencySource_DetectMethod(t *testing.T) {
+	depSource := NewMemorySource(map[string][]byte{
+		"composer.lock":             []byte("{}"),
+		"requirements.txt":          []byte(""),
+		"Cargo.toml":                []byte(""),
+		"gradle/libs.versions.toml": []byte(""),
+	})
+	types, err := depSource.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on memory source detection: %v", err)
+	}
+	if !reflect.DeepEqual(types, []DepType{ComposerType, PIPType, CargoType, GradleType}) {
+		t.Errorf("unexpected detected types from mem source: %+v", types)
+	}
+
+	types, err = NewMemorySource(map[string][]byte{}).Detect(context.Background())
+	if err != nil || len(types) != 0 {
+		t.Errorf("expected no types and no error from empty source, got: %+v, %v", types, err)
+	}
+
+	gitDepSource := GitDependencySource{fetcher: failingFetcher{err: errors.New("rate limit exceeded")}}
+	types, err = gitDepSource.Detect(context.Background())
+	if err == nil || err.Error() != `unable to detect "composer" package manager files: rate limit exceeded` {
+		t.Errorf("expected fetcher error on detection, got: %v", err)
+	}
+	if types != nil {
+		t.Errorf("expected nil types on detection error, got: %+v", types)
+	}
+}
+
+func TestDependencySource_DetectListing(t *testing.T) {
+	requests := 0
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.RequestURI() != "/repos/hello/world/git/trees/HEAD?recursive=1" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"sha": "root", "tree": [
+			{"path": "composer.lock", "type": "blob"},
+			{"path": "gradle", "type": "tree"},
+			{"path": "gradle/libs.versions.toml", "type": "blob"},
+			{"path": "services/requirements.txt", "type": "blob"}
+		]}`))
+	}))
+
+	depSource, err := NewGitSource(cl, "git@github.com/hello/world.git", "")
+	if err != nil {
+		t.Fatalf("unexpected error on new git source: %v", err)
+	}
+	types, err := depSource.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on git source detection: %v", err)
+	}
+	if !reflect.DeepEqual(types, []DepType{ComposerType, GradleType}) {
+		t.Errorf("unexpected detected types from git source: %+v", types)
+	}
+	if requests != 1 {
+		t.Errorf("expected detection to take one request, got %d", requests)
+	}
+}
+
+func TestDependencySource_UnsupportedType(t *testing.T) {
+	depSource := NewMemorySource(fileMapMockData)
+
+	resCnsts, err := depSource.Constraints(context.Background(), DepType("npm"))
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected unsupported type error, got: %v", err)
+	}
+	if resCnsts != nil {
+		t.Errorf("expected nil result on unsupported type, got: %+v", resCnsts)
+	}
+
+	resReqs, err := depSource.Requirements(context.Background(), DepType(""))
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected unsupported type error, got: %v", err)
+	}
+	if resReqs != nil {
+		t.Errorf("expected nil result on unsupported type, got: %+v", resReqs)
+	}
+}
@@ -3,7 +3,9 @@ module github.com/dephub/dephub-core
 go 1.14
 
 require (
+	github.com/BurntSushi/toml v0.4.1
 	github.com/google/go-github/v33 v33.0.0
 	github.com/google/go-querystring v1.0.0
 	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
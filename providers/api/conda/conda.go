@@ -0,0 +1,163 @@
+/*
+Package conda provides a client for reading conda channels repository data.
+
+Usage:
+	todo:
+*/
+package conda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dephub/dephub-core/providers/transport"
+)
+
+// condaChannelsBaseURL - anaconda.org channels base url (used as default client baseURL)
+var condaChannelsBaseURL *url.URL
+
+// condaChannelsHostname - anaconda.org channels address (used as default channels location).
+//
+// Community channels (e.g. 'conda-forge' or 'bioconda') are served from 'conda.anaconda.org/{channel}',
+// you can get more info on the channels layout here: docs.conda.io/projects/conda/en/latest/user-guide/concepts/channels.html
+var condaChannelsHostname string = "https://conda.anaconda.org"
+
+// condaDefaultsHostname - 'defaults' channel address, used only with the default channels location.
+var condaDefaultsHostname string = "https://repo.anaconda.com/pkgs/main"
+
+func init() {
+	condaChannelsBaseURL, _ = url.Parse(condaChannelsHostname)
+}
+
+// Client represents conda channels client interface.
+type Client interface {
+	// RepoData method is used to get channel's subdir (platform) index, containing all the published packages.
+	RepoData(ctx context.Context, channel, subdir string) (*RepoData, *http.Response, error)
+}
+
+// NewChannelClient constructs a new ChannelClient.
+//
+// If httpClient or URL is nil - default values will be used.
+// URL is the location of the channels (e.g. 'https://conda.anaconda.org' or a mirror address),
+// channel's repository data is expected at '{URL}/{channel}/{subdir}/repodata.json'.
+func NewChannelClient(httpClient *http.Client, URL *url.URL) Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if URL == nil {
+		return &ChannelClient{httpClient: httpClient, baseURL: *condaChannelsBaseURL, defaultsURL: condaDefaultsHostname}
+	}
+	return &ChannelClient{httpClient: httpClient, baseURL: *URL}
+}
+
+// NewLocalChannelClient constructs a new ChannelClient reading repository data from the local directory.
+//
+// The directory must keep the channels layout (e.g. '{dir}/conda-forge/noarch/repodata.json'),
+// which is the case for the channels mirrors (e.g. created by 'conda-mirror').
+func NewLocalChannelClient(dir string) Client {
+	httpClient := &http.Client{Transport: http.NewFileTransport(http.Dir(dir))}
+	return &ChannelClient{httpClient: httpClient, baseURL: url.URL{Scheme: "file", Path: "/"}}
+}
+
+// ChannelClient is used to communicate with conda channels (e.g. 'conda.anaconda.org/conda-forge').
+type ChannelClient struct {
+	httpClient  *http.Client
+	baseURL     url.URL
+	defaultsURL string // 'defaults' channel address (empty if it is served from the baseURL)
+}
+
+// RepoData method is used to get channel's subdir (platform) index, containing all the published packages.
+//
+// Channel can be either a name (e.g. 'conda-forge') or a full channel URL.
+// Subdir is a platform name (e.g. 'linux-64' or 'noarch').
+func (cc ChannelClient) RepoData(ctx context.Context, channel, subdir string) (*RepoData, *http.Response, error) {
+	if channel == "" || subdir == "" {
+		return nil, nil, fmt.Errorf("'channel' and 'subdir' options are required for repodata request")
+	}
+
+	route := fmt.Sprintf("%s/%s/repodata.json", cc.ChannelURL(channel), subdir)
+	req, err := http.NewRequestWithContext(ctx, "GET", route, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	resp, err := cc.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+	if err = transport.CheckRateLimit(resp); err != nil {
+		return nil, resp, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, resp, fmt.Errorf("conda channel responded with HTTP error '%d: %s'", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp, fmt.Errorf("unable to read the response body: %w", err)
+	}
+
+	var rd RepoData
+	if err = json.Unmarshal(body, &rd); err != nil {
+		return nil, resp, fmt.Errorf("unable to parse the response body: %w", err)
+	}
+
+	return &rd, resp, nil
+}
+
+// ChannelURL returns channel address, full channel URLs are returned as is.
+func (cc ChannelClient) ChannelURL(channel string) string {
+	channel = strings.TrimSuffix(channel, "/")
+	if strings.Contains(channel, "://") {
+		return channel
+	}
+	if channel == "defaults" && cc.defaultsURL != "" {
+		return cc.defaultsURL
+	}
+	return strings.TrimSuffix(cc.baseURL.String(), "/") + "/" + channel
+}
+
+// RepoData represents channel's subdir index file (repodata.json).
+//
+// Packages are keyed by their file names, '.tar.bz2' packages are stored
+// in 'packages' and '.conda' packages are stored in 'packages.conda'.
+type RepoData struct {
+	Info struct {
+		Subdir string `json:"subdir"`
+	} `json:"info"`
+	Packages      map[string]PackageRecord `json:"packages"`
+	PackagesConda map[string]PackageRecord `json:"packages.conda"`
+	Removed       []string                 `json:"removed"`
+}
+
+// Records returns all the package builds published in the subdir (from both packages formats).
+func (rd RepoData) Records(name string) []PackageRecord {
+	var res []PackageRecord
+	for _, pkgs := range []map[string]PackageRecord{rd.Packages, rd.PackagesConda} {
+		for _, rec := range pkgs {
+			if rec.Name == name {
+				res = append(res, rec)
+			}
+		}
+	}
+	return res
+}
+
+// PackageRecord represents one published package build.
+type PackageRecord struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Build       string   `json:"build"`
+	BuildNumber int      `json:"build_number"`
+	Depends     []string `json:"depends"`
+	License     string   `json:"license"`
+	MD5         string   `json:"md5"`
+	SHA256      string   `json:"sha256"`
+	Size        int64    `json:"size"`
+	Subdir      string   `json:"subdir"`
+	Timestamp   int64    `json:"timestamp"`
+}
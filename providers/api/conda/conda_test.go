@@ -0,0 +1,150 @@
+package conda
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNewChannelClientMethod(t *testing.T) {
+	cl := NewChannelClient(nil, nil)
+	cc := cl.(*ChannelClient)
+
+	if cc.httpClient != http.DefaultClient {
+		t.Errorf("default httpClient is not set on NewChannelClient instance")
+	}
+	if cc.baseURL != *condaChannelsBaseURL {
+		t.Errorf("default baseURL is not set on NewChannelClient instance")
+	}
+
+	cases := map[string]string{
+		"conda-forge":                         "https://conda.anaconda.org/conda-forge",
+		"defaults":                            "https://repo.anaconda.com/pkgs/main",
+		"https://repo.example.com/pkgs/free/": "https://repo.example.com/pkgs/free",
+	}
+	for channel, expected := range cases {
+		if u := cc.ChannelURL(channel); u != expected {
+			t.Errorf("unexpected channel url for %q, expected %q, got %q", channel, expected, u)
+		}
+	}
+
+	URL, _ := url.Parse("https://mirror.example.com/conda/")
+	cc = NewChannelClient(nil, URL).(*ChannelClient)
+	if u := cc.ChannelURL("defaults"); u != "https://mirror.example.com/conda/defaults" {
+		t.Errorf("unexpected mirror channel url %q", u)
+	}
+}
+
+func TestChannelClientRepoDataMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		expectedPath := "/conda-forge/noarch/repodata.json"
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected url call is %q, got %q", expectedPath, r.URL.Path)
+		}
+		_, _ = rw.Write([]byte(repoDataFixture))
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL)
+	cl := NewChannelClient(srv.Client(), URL)
+	rd, _, err := cl.RepoData(context.Background(), "conda-forge", "noarch")
+	if err != nil {
+		t.Fatalf("unexpected RepoData() error: %v", err)
+	}
+
+	testRepoData(t, rd)
+}
+
+func TestChannelClientRepoDataMethod_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken/noarch/repodata.json" {
+			_, _ = rw.Write([]byte("{broken"))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL)
+	cl := NewChannelClient(srv.Client(), URL)
+	cases := [][2]string{{"", "noarch"}, {"missing", "noarch"}, {"broken", "noarch"}}
+	for _, cs := range cases {
+		rd, _, err := cl.RepoData(context.Background(), cs[0], cs[1])
+		if err == nil {
+			t.Errorf("expected error on %q/%q repodata, got none", cs[0], cs[1])
+		}
+		if rd != nil {
+			t.Errorf("expected nil repodata on error, got: %+v", rd)
+		}
+	}
+}
+
+func TestLocalChannelClientRepoDataMethod(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conda-mirror")
+	if err != nil {
+		t.Fatalf("unable to create temporary mirror directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = os.MkdirAll(filepath.Join(dir, "conda-forge", "noarch"), 0755); err != nil {
+		t.Fatalf("unable to create mirror directories: %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "conda-forge", "noarch", "repodata.json"), []byte(repoDataFixture), 0644); err != nil {
+		t.Fatalf("unable to write repodata file: %v", err)
+	}
+
+	cl := NewLocalChannelClient(dir)
+	rd, _, err := cl.RepoData(context.Background(), "conda-forge", "noarch")
+	if err != nil {
+		t.Fatalf("unexpected RepoData() error: %v", err)
+	}
+	testRepoData(t, rd)
+
+	rd, _, err = cl.RepoData(context.Background(), "conda-forge", "linux-64")
+	if err == nil || rd != nil {
+		t.Errorf("expected error on missing subdir, got: %+v, %v", rd, err)
+	}
+}
+
+// testRepoData checks repoDataFixture parsing results.
+func testRepoData(t *testing.T, rd *RepoData) {
+	t.Helper()
+	if rd.Info.Subdir != "noarch" || !reflect.DeepEqual(rd.Removed, []string{"requests-2.0.0-py_0.tar.bz2"}) {
+		t.Errorf("unexpected repodata info: %+v", rd)
+	}
+
+	records := rd.Records("requests")
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Version < records[j].Version
+	})
+	expected := []PackageRecord{
+		{Name: "requests", Version: "2.25.1", Build: "pyhd3deb0d_0", Depends: []string{"python >=3.6"}, Subdir: "noarch", Timestamp: 1608000000000},
+		{Name: "requests", Version: "2.26.0", Build: "pyhd8ed1ab_0", BuildNumber: 1, Depends: []string{"python >=3.6", "urllib3 >=1.21.1,<1.27"}, License: "Apache-2.0", Subdir: "noarch"},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("unexpected requests records, got: %+v", records)
+	}
+	if records := rd.Records("missing"); records != nil {
+		t.Errorf("expected no records for missing package, got: %+v", records)
+	}
+}
+
+var repoDataFixture = `{
+	"info": {"subdir": "noarch"},
+	"packages": {
+		"requests-2.25.1-pyhd3deb0d_0.tar.bz2": {"name": "requests", "version": "2.25.1", "build": "pyhd3deb0d_0", "build_number": 0, "depends": ["python >=3.6"], "subdir": "noarch", "timestamp": 1608000000000},
+		"six-1.16.0-pyh6c4a22f_0.tar.bz2": {"name": "six", "version": "1.16.0", "build": "pyh6c4a22f_0", "depends": [], "subdir": "noarch"}
+	},
+	"packages.conda": {
+		"requests-2.26.0-pyhd8ed1ab_0.conda": {"name": "requests", "version": "2.26.0", "build": "pyhd8ed1ab_0", "build_number": 1, "depends": ["python >=3.6", "urllib3 >=1.21.1,<1.27"], "license": "Apache-2.0", "subdir": "noarch"}
+	},
+	"removed": ["requests-2.0.0-py_0.tar.bz2"],
+	"repodata_version": 1
+}`
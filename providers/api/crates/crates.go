@@ -0,0 +1,174 @@
+/*
+Package crates provides a client for using the crates.io sparse registry index.
+
+Usage:
+	todo:
+*/
+package crates
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dephub/dephub-core/providers/transport"
+)
+
+// cratesIndexBaseURL - crates.io sparse index base url (used as default client baseURL)
+var cratesIndexBaseURL *url.URL
+
+// cratesIndexHostname - crates.io sparse index hostname (used as default index).
+//
+// crates.io is the Rust community's crate registry. You can get more info on
+// the sparse index protocol here: doc.rust-lang.org/cargo/reference/registry-index.html
+var cratesIndexHostname string = "https://index.crates.io"
+
+func init() {
+	cratesIndexBaseURL, _ = url.Parse(cratesIndexHostname)
+}
+
+// Client represents crates registry index client interface.
+type Client interface {
+	// Crate method is used to get information about crate and all of it's published versions.
+	Crate(ctx context.Context, name string) (*Crate, *http.Response, error)
+}
+
+// NewSparseIndexClient constructs a new SparseIndexClient.
+//
+// If httpClient or URL is nil - default values will be used.
+// Pass URL only if you are sure that the address is compatible with sparse index protocol.
+func NewSparseIndexClient(httpClient *http.Client, URL *url.URL) Client {
+	if URL == nil {
+		URL = cratesIndexBaseURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &SparseIndexClient{httpClient: httpClient, baseURL: *URL}
+}
+
+// NewLocalIndexClient constructs a new SparseIndexClient reading index files from the local directory.
+//
+// The directory must keep the sparse index layout (e.g. '{dir}/se/rd/serde'),
+// which is the case for the registry mirrors and for cargo's own index cache.
+func NewLocalIndexClient(dir string) Client {
+	httpClient := &http.Client{Transport: http.NewFileTransport(http.Dir(dir))}
+	return &SparseIndexClient{httpClient: httpClient, baseURL: url.URL{Scheme: "file", Path: "/"}}
+}
+
+// SparseIndexClient is used to communicate with sparse registry index (e.g. 'index.crates.io').
+type SparseIndexClient struct {
+	httpClient *http.Client
+	baseURL    url.URL
+}
+
+// Crate method is used to get information about crate and all of it's published versions.
+//
+// Versions are returned in the index order, which is the publishing order.
+func (sc SparseIndexClient) Crate(ctx context.Context, name string) (*Crate, *http.Response, error) {
+	path, err := IndexPath(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	route := fmt.Sprintf("%s/%s", strings.TrimSuffix(sc.baseURL.String(), "/"), path)
+	req, err := http.NewRequestWithContext(ctx, "GET", route, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	resp, err := sc.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+	if err = transport.CheckRateLimit(resp); err != nil {
+		return nil, resp, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, resp, fmt.Errorf("crates index responded with HTTP error '%d: %s'", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp, fmt.Errorf("unable to read the response body: %w", err)
+	}
+
+	crate := Crate{Name: name}
+	// Every line of the index file is a JSON document describing one version
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var v CrateVersion
+		if err = json.Unmarshal(line, &v); err != nil {
+			return nil, resp, fmt.Errorf("unable to parse the response body: %w", err)
+		}
+		crate.Name = v.Name
+		crate.Versions = append(crate.Versions, v)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, resp, fmt.Errorf("unable to read the response body: %w", err)
+	}
+
+	return &crate, resp, nil
+}
+
+// IndexPath returns crate's file path in the registry index.
+//
+// Crate names are lowercased and split into the directories by their length:
+// 'a' -> '1/a', 'ab' -> '2/ab', 'abc' -> '3/a/abc', 'serde' -> 'se/rd/serde'. Empty name is an error.
+func IndexPath(name string) (string, error) {
+	name = strings.ToLower(name)
+	switch len(name) {
+	case 0:
+		return "", fmt.Errorf("crate name is required and can't be empty")
+	case 1:
+		return "1/" + name, nil
+	case 2:
+		return "2/" + name, nil
+	case 3:
+		return "3/" + name[:1] + "/" + name, nil
+	}
+	return name[:2] + "/" + name[2:4] + "/" + name, nil
+}
+
+// Crate represents crate information with all of it's published versions.
+type Crate struct {
+	Name     string
+	Versions []CrateVersion
+}
+
+// CrateVersion represents one published crate version (one line of the index file).
+type CrateVersion struct {
+	Name        string              `json:"name"`
+	Version     string              `json:"vers"`
+	Deps        []CrateDependency   `json:"deps"`
+	Checksum    string              `json:"cksum"`
+	Features    map[string][]string `json:"features"`
+	Features2   map[string][]string `json:"features2"`
+	Yanked      bool                `json:"yanked"`
+	Links       string              `json:"links"`
+	Schema      int                 `json:"v"`
+	RustVersion string              `json:"rust_version"`
+}
+
+// CrateDependency represents one dependency of the published crate version.
+type CrateDependency struct {
+	Name            string   `json:"name"`
+	Req             string   `json:"req"`
+	Features        []string `json:"features"`
+	Optional        bool     `json:"optional"`
+	DefaultFeatures bool     `json:"default_features"`
+	Target          string   `json:"target"`
+	Kind            string   `json:"kind"`
+	Registry        string   `json:"registry"`
+	Package         string   `json:"package"`
+}
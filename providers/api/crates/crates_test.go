@@ -0,0 +1,140 @@
+package crates
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewSparseIndexClientMethod(t *testing.T) {
+	cl := NewSparseIndexClient(nil, nil)
+	sc := cl.(*SparseIndexClient)
+
+	if sc.httpClient != http.DefaultClient {
+		t.Errorf("default httpClient is not set on NewSparseIndexClient instance")
+	}
+	if sc.baseURL != *cratesIndexBaseURL {
+		t.Errorf("default baseURL is not set on NewSparseIndexClient instance")
+	}
+}
+
+func TestIndexPath(t *testing.T) {
+	cases := map[string]string{
+		"a":          "1/a",
+		"ab":         "2/ab",
+		"abc":        "3/a/abc",
+		"serde":      "se/rd/serde",
+		"Serde_JSON": "se/rd/serde_json",
+	}
+	for name, expected := range cases {
+		if p, err := IndexPath(name); err != nil || p != expected {
+			t.Errorf("unexpected index path for %q, expected %q, got %q (%v)", name, expected, p, err)
+		}
+	}
+	if p, err := IndexPath(""); err == nil {
+		t.Errorf("expected error on empty crate name, got %q", p)
+	}
+}
+
+func TestSparseIndexClientCrateMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		expectedPath := "/se/rd/serde"
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected url call is %q, got %q", expectedPath, r.URL.Path)
+		}
+		_, _ = rw.Write([]byte(serdeIndexFixture))
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL)
+	cl := NewSparseIndexClient(srv.Client(), URL)
+	crate, _, err := cl.Crate(context.Background(), "serde")
+	if err != nil {
+		t.Fatalf("unexpected Crate() error: %v", err)
+	}
+
+	if !reflect.DeepEqual(crate, expectedSerdeCrate) {
+		t.Errorf("unexpected crate, got: %+v", crate)
+	}
+}
+
+func TestSparseIndexClientCrateMethod_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/br/ok/broken" {
+			_, _ = rw.Write([]byte("{broken"))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL)
+	cl := NewSparseIndexClient(srv.Client(), URL)
+	for _, name := range []string{"", "missing", "broken"} {
+		crate, _, err := cl.Crate(context.Background(), name)
+		if err == nil {
+			t.Errorf("expected error on %q crate, got none", name)
+		}
+		if crate != nil {
+			t.Errorf("expected nil crate on error, got: %+v", crate)
+		}
+	}
+}
+
+func TestLocalIndexClientCrateMethod(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crates-index")
+	if err != nil {
+		t.Fatalf("unable to create temporary index directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = os.MkdirAll(filepath.Join(dir, "se", "rd"), 0755); err != nil {
+		t.Fatalf("unable to create index directories: %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "se", "rd", "serde"), []byte(serdeIndexFixture), 0644); err != nil {
+		t.Fatalf("unable to write index file: %v", err)
+	}
+
+	cl := NewLocalIndexClient(dir)
+	crate, _, err := cl.Crate(context.Background(), "serde")
+	if err != nil {
+		t.Fatalf("unexpected Crate() error: %v", err)
+	}
+	if !reflect.DeepEqual(crate, expectedSerdeCrate) {
+		t.Errorf("unexpected crate, got: %+v", crate)
+	}
+
+	crate, _, err = cl.Crate(context.Background(), "missing")
+	if err == nil || crate != nil {
+		t.Errorf("expected error on missing crate, got: %+v, %v", crate, err)
+	}
+}
+
+var serdeIndexFixture = `{"name":"serde","vers":"1.0.0","deps":[{"name":"serde_derive","req":"^1.0","features":[],"optional":true,"default_features":true,"target":null,"kind":"normal"}],"cksum":"aaa","features":{"derive":["serde_derive"]},"yanked":false,"links":null}
+{"name":"serde","vers":"1.0.1","deps":[],"cksum":"bbb","features":{},"yanked":true}
+
+{"name":"serde","vers":"1.0.130","deps":[],"cksum":"ccc","features":{},"yanked":false,"v":2,"features2":{"std":[]},"rust_version":"1.15"}
+`
+
+var expectedSerdeCrate = &Crate{
+	Name: "serde",
+	Versions: []CrateVersion{
+		{
+			Name:    "serde",
+			Version: "1.0.0",
+			Deps: []CrateDependency{
+				{Name: "serde_derive", Req: "^1.0", Features: []string{}, Optional: true, DefaultFeatures: true, Kind: "normal"},
+			},
+			Checksum: "aaa",
+			Features: map[string][]string{"derive": {"serde_derive"}},
+		},
+		{Name: "serde", Version: "1.0.1", Deps: []CrateDependency{}, Checksum: "bbb", Features: map[string][]string{}, Yanked: true},
+		{Name: "serde", Version: "1.0.130", Deps: []CrateDependency{}, Checksum: "ccc", Features: map[string][]string{}, Schema: 2, Features2: map[string][]string{"std": {}}, RustVersion: "1.15"},
+	},
+}
@@ -0,0 +1,116 @@
+/*
+Package maven provides a client for reading Maven repositories metadata.
+
+Usage:
+	todo:
+*/
+package maven
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dephub/dephub-core/providers/transport"
+)
+
+// mavenCentralBaseURL - Maven Central repository base url (used as default client baseURL)
+var mavenCentralBaseURL *url.URL
+
+// mavenCentralHostname - Maven Central repository address (used as default repository).
+//
+// Maven Central is the default repository used by Maven and Gradle builds. Any other
+// repository with the standard Maven 2 layout (Nexus, Artifactory, etc.) can be used instead.
+var mavenCentralHostname string = "https://repo.maven.apache.org/maven2"
+
+func init() {
+	mavenCentralBaseURL, _ = url.Parse(mavenCentralHostname)
+}
+
+// Client represents maven repository client interface.
+type Client interface {
+	// Metadata method is used to get artifact metadata (maven-metadata.xml), containing all the published versions.
+	Metadata(ctx context.Context, groupID, artifactID string) (*Metadata, *http.Response, error)
+}
+
+// NewRepositoryClient constructs a new RepositoryClient.
+//
+// If httpClient or URL is nil - default values will be used.
+// Pass URL only if you are sure that the repository uses standard Maven 2 layout.
+func NewRepositoryClient(httpClient *http.Client, URL *url.URL) Client {
+	if URL == nil {
+		URL = mavenCentralBaseURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &RepositoryClient{httpClient: httpClient, baseURL: *URL}
+}
+
+// RepositoryClient is used to communicate with Maven 2 layout compatible repository.
+type RepositoryClient struct {
+	httpClient *http.Client
+	baseURL    url.URL
+}
+
+// BaseURL returns the repository address (Maven Central address if the default one is used).
+func (rc RepositoryClient) BaseURL() url.URL {
+	return rc.baseURL
+}
+
+// Metadata method is used to get artifact metadata (maven-metadata.xml), containing all the published versions.
+func (rc RepositoryClient) Metadata(ctx context.Context, groupID, artifactID string) (*Metadata, *http.Response, error) {
+	if groupID == "" || artifactID == "" {
+		return nil, nil, fmt.Errorf("'groupID' and 'artifactID' options are required for metadata request")
+	}
+
+	route := fmt.Sprintf("%s/%s/maven-metadata.xml", strings.TrimSuffix(rc.baseURL.String(), "/"), ArtifactPath(groupID, artifactID))
+	req, err := http.NewRequestWithContext(ctx, "GET", route, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	resp, err := rc.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+	if err = transport.CheckRateLimit(resp); err != nil {
+		return nil, resp, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, resp, fmt.Errorf("maven repository responded with HTTP error '%d: %s'", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp, fmt.Errorf("unable to read the response body: %w", err)
+	}
+
+	var md Metadata
+	if err = xml.Unmarshal(body, &md); err != nil {
+		return nil, resp, fmt.Errorf("unable to parse the response body: %w", err)
+	}
+
+	return &md, resp, nil
+}
+
+// ArtifactPath returns artifact directory path in the repository (e.g. 'org/slf4j/slf4j-api').
+func ArtifactPath(groupID, artifactID string) string {
+	return strings.ReplaceAll(groupID, ".", "/") + "/" + artifactID
+}
+
+// Metadata represents artifact metadata file (maven-metadata.xml).
+type Metadata struct {
+	XMLName    xml.Name `xml:"metadata"`
+	GroupID    string   `xml:"groupId"`
+	ArtifactID string   `xml:"artifactId"`
+	Versioning struct {
+		Latest      string   `xml:"latest"`
+		Release     string   `xml:"release"`
+		Versions    []string `xml:"versions>version"`
+		LastUpdated string   `xml:"lastUpdated"`
+	} `xml:"versioning"`
+}
@@ -0,0 +1,95 @@
+package maven
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestNewRepositoryClientMethod(t *testing.T) {
+	cl := NewRepositoryClient(nil, nil)
+	rc := cl.(*RepositoryClient)
+
+	if rc.httpClient != http.DefaultClient {
+		t.Errorf("default httpClient is not set on NewRepositoryClient instance")
+	}
+	if rc.baseURL != *mavenCentralBaseURL {
+		t.Errorf("default baseURL is not set on NewRepositoryClient instance")
+	}
+	if base := rc.BaseURL(); base.String() != "https://repo.maven.apache.org/maven2" {
+		t.Errorf("unexpected repository address %q", base.String())
+	}
+	if p := ArtifactPath("org.slf4j", "slf4j-api"); p != "org/slf4j/slf4j-api" {
+		t.Errorf("unexpected artifact path %q", p)
+	}
+}
+
+func TestRepositoryClientMetadataMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		expectedPath := "/repository/maven-public/org/slf4j/slf4j-api/maven-metadata.xml"
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected url call is %q, got %q", expectedPath, r.URL.Path)
+		}
+		rw.Header().Set("Content-Type", "text/xml")
+		_, _ = rw.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+			<metadata>
+				<groupId>org.slf4j</groupId>
+				<artifactId>slf4j-api</artifactId>
+				<versioning>
+					<latest>2.0.0-alpha5</latest>
+					<release>1.7.32</release>
+					<versions>
+						<version>1.7.30</version>
+						<version>1.7.32</version>
+						<version>2.0.0-alpha5</version>
+					</versions>
+					<lastUpdated>20210829101011</lastUpdated>
+				</versioning>
+			</metadata>`))
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL + "/repository/maven-public/")
+	cl := NewRepositoryClient(srv.Client(), URL)
+	md, _, err := cl.Metadata(context.Background(), "org.slf4j", "slf4j-api")
+	if err != nil {
+		t.Fatalf("unexpected Metadata() error: %v", err)
+	}
+
+	if md.GroupID != "org.slf4j" || md.ArtifactID != "slf4j-api" {
+		t.Errorf("unexpected metadata coordinates: %+v", md)
+	}
+	if md.Versioning.Latest != "2.0.0-alpha5" || md.Versioning.Release != "1.7.32" || md.Versioning.LastUpdated != "20210829101011" {
+		t.Errorf("unexpected metadata versioning: %+v", md.Versioning)
+	}
+	if !reflect.DeepEqual(md.Versioning.Versions, []string{"1.7.30", "1.7.32", "2.0.0-alpha5"}) {
+		t.Errorf("unexpected metadata versions: %+v", md.Versioning.Versions)
+	}
+}
+
+func TestRepositoryClientMetadataMethod_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken/artifact/maven-metadata.xml" {
+			_, _ = rw.Write([]byte("<metadata><broken"))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL)
+	cl := NewRepositoryClient(srv.Client(), URL)
+	cases := [][2]string{{"", "artifact"}, {"missing", "artifact"}, {"broken", "artifact"}}
+	for _, cs := range cases {
+		md, _, err := cl.Metadata(context.Background(), cs[0], cs[1])
+		if err == nil {
+			t.Errorf("expected error on %q:%q metadata, got none", cs[0], cs[1])
+		}
+		if md != nil {
+			t.Errorf("expected nil metadata on error, got: %+v", md)
+		}
+	}
+}
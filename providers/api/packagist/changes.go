@@ -0,0 +1,132 @@
+package packagist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metadata changes actions types.
+const (
+	// ChangeUpdate means the package metadata was updated (e.g. new release was published).
+	ChangeUpdate = "update"
+	// ChangeDelete means the package was deleted.
+	ChangeDelete = "delete"
+	// ChangeResync means the cursor is too old, all the packages metadata must be considered changed.
+	ChangeResync = "resync"
+)
+
+// ChangeAction represents the package metadata change.
+type ChangeAction struct {
+	Type string `json:"type"`
+	// Package is the package name, development versions changes are reported with '~dev' suffix.
+	Package string `json:"package"`
+	// Time is the change time (unix timestamp).
+	Time int64 `json:"time"`
+}
+
+// MetadataChanges represents '/metadata/changes.json' response.
+type MetadataChanges struct {
+	Actions []ChangeAction `json:"actions"`
+	// Timestamp is the cursor to request the following changes with.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Updated returns unique names of the updated packages (without '~dev' suffix) in the changes order.
+func (mc MetadataChanges) Updated() []string {
+	return mc.packages(ChangeUpdate)
+}
+
+// Deleted returns unique names of the deleted packages in the changes order.
+func (mc MetadataChanges) Deleted() []string {
+	return mc.packages(ChangeDelete)
+}
+
+// Resync reports whether the cursor was too old and every package must be considered changed.
+func (mc MetadataChanges) Resync() bool {
+	for _, a := range mc.Actions {
+		if a.Type == ChangeResync {
+			return true
+		}
+	}
+	return false
+}
+
+// packages - helper to get unique packages names of the actions type.
+func (mc MetadataChanges) packages(typ string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, a := range mc.Actions {
+		name := strings.TrimSuffix(a.Package, "~dev")
+		if a.Type != typ || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// ChangesTimestamp converts the time into metadata changes cursor (Packagist uses 1/10000 second precision).
+func ChangesTimestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(100*time.Microsecond)
+}
+
+// Changes method returns packages metadata changes since the cursor (previous response timestamp).
+//
+// If since is not positive no request is sent, current time cursor is returned to start receiving changes from.
+func (c PackagistClient) Changes(ctx context.Context, since int64) (*MetadataChanges, *http.Response, error) {
+	if since <= 0 {
+		return &MetadataChanges{Timestamp: ChangesTimestamp(time.Now())}, nil, nil
+	}
+
+	route := fmt.Sprintf("%s/metadata/changes.json?since=%d", &c.baseURL, since)
+	req, err := http.NewRequestWithContext(ctx, "GET", route, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+
+	var mc MetadataChanges
+	var r *http.Response
+	if r, err = c.parseResponse(req, &mc); err != nil {
+		return nil, nil, err
+	}
+
+	return &mc, r, nil
+}
+
+// NewChangesFeed constructs metadata changes feed starting from the cursor (current time if it's not positive).
+func NewChangesFeed(client Client, since int64) *ChangesFeed {
+	return &ChangesFeed{client: client, since: since}
+}
+
+// ChangesFeed tracks metadata changes cursor between Next() calls, it's safe for concurrent use.
+type ChangesFeed struct {
+	client Client
+
+	mu    sync.Mutex
+	since int64
+}
+
+// Next returns the changes since the previous call and moves the cursor, the cursor is kept on errors.
+func (f *ChangesFeed) Next(ctx context.Context) (*MetadataChanges, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	mc, _, err := f.client.Changes(ctx, f.since)
+	if err != nil {
+		return nil, err
+	}
+	f.since = mc.Timestamp
+	return mc, nil
+}
+
+// Since returns the current cursor (store it to resume the feed later).
+func (f *ChangesFeed) Since() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.since
+}
@@ -0,0 +1,90 @@
+package packagist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChangesMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/changes.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("since") {
+		case "16000000000000":
+			_, _ = rw.Write([]byte(`{"actions": [
+				{"type": "update", "package": "hello/world", "time": 1600000001},
+				{"type": "update", "package": "hello/world~dev", "time": 1600000002},
+				{"type": "delete", "package": "old/package", "time": 1600000003},
+				{"type": "update", "package": "acme/lib", "time": 1600000004}
+			], "timestamp": 16000000050000}`))
+		case "16000000050000":
+			_, _ = rw.Write([]byte(`{"actions": [{"type": "resync", "package": "*", "time": 1600000006}], "timestamp": 16000000060000}`))
+		default:
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL)
+	cl, err := NewClient(srv.Client(), URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	feed := NewChangesFeed(cl, 16000000000000)
+	changes, err := feed.Next(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated := changes.Updated(); !reflect.DeepEqual(updated, []string{"hello/world", "acme/lib"}) {
+		t.Fatalf("unexpected updated packages: %v", updated)
+	}
+	if deleted := changes.Deleted(); !reflect.DeepEqual(deleted, []string{"old/package"}) {
+		t.Fatalf("unexpected deleted packages: %v", deleted)
+	}
+	if changes.Resync() {
+		t.Fatalf("unexpected resync")
+	}
+	if feed.Since() != 16000000050000 {
+		t.Fatalf("expected cursor to be moved, got %d", feed.Since())
+	}
+
+	changes, err = feed.Next(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changes.Resync() {
+		t.Fatalf("expected resync")
+	}
+
+	// Failed request keeps the cursor
+	feed = NewChangesFeed(cl, 1)
+	if _, err = feed.Next(context.Background()); err == nil {
+		t.Fatalf("expected error")
+	}
+	if feed.Since() != 1 {
+		t.Fatalf("expected cursor to be kept, got %d", feed.Since())
+	}
+}
+
+func TestChangesMethod_InitialCursor(t *testing.T) {
+	cl, _ := NewClient(nil, nil)
+	before := ChangesTimestamp(time.Now())
+	changes, resp, err := cl.Changes(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != nil || len(changes.Actions) != 0 {
+		t.Fatalf("expected no request to be sent")
+	}
+	if changes.Timestamp < before || changes.Timestamp > ChangesTimestamp(time.Now()) {
+		t.Fatalf("expected current time cursor, got %d", changes.Timestamp)
+	}
+}
@@ -0,0 +1,183 @@
+package packagist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotModified is returned when the requested metadata wasn't modified since the passed time.
+var ErrNotModified = errors.New("metadata is not modified")
+
+// minifiedFormat - Composer v2 minified metadata format identifier.
+const minifiedFormat = "composer/2.0"
+
+// RepositoryInfo represents Composer repository 'packages.json' information.
+type RepositoryInfo struct {
+	// MetadataURL is Composer v2 metadata URL template (e.g. '/p2/%package%.json'), empty for Composer v1 repositories.
+	MetadataURL string `json:"metadata-url"`
+	// AvailablePackages lists all the repository packages (if the repository provides such list).
+	AvailablePackages []string `json:"available-packages"`
+	// AvailablePackagePatterns lists the repository packages names patterns (e.g. 'vendor/*').
+	AvailablePackagePatterns []string `json:"available-package-patterns"`
+	// ProvidersURL is Composer v1 providers URL template.
+	ProvidersURL string `json:"providers-url"`
+}
+
+// MetaV2Options specifies the optional parameters to MetaV2() method.
+type MetaV2Options struct {
+	// Dev requests development versions (branches) metadata ('~dev.json') instead of tagged releases.
+	Dev bool
+	// ModifiedSince is sent as 'If-Modified-Since' header, ErrNotModified is returned if metadata wasn't modified.
+	ModifiedSince time.Time
+}
+
+// metadataURL represents discovered Composer v2 metadata URL template.
+type metadataURL struct {
+	mu       sync.Mutex
+	template string
+}
+
+// Repository method returns the repository 'packages.json' information (e.g. Composer v2 metadata URL template).
+func (c PackagistClient) Repository(ctx context.Context) (*RepositoryInfo, *http.Response, error) {
+	route := fmt.Sprintf("%s/packages.json", &c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", route, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+
+	var ri RepositoryInfo
+	var r *http.Response
+	if r, err = c.parseResponse(req, &ri); err != nil {
+		return nil, nil, err
+	}
+
+	return &ri, r, nil
+}
+
+// MetaV2 method is used to get package metadata with Composer v2 metadata protocol ('/p2/{vendor}/{pkg}.json').
+//
+// Minified responses are expanded, versions are kept in the repository order (usually the newest first).
+// Metadata URL template is discovered from the repository 'packages.json' once per client.
+func (c PackagistClient) MetaV2(ctx context.Context, vendor, pkg string, opts *MetaV2Options) (*PackagesMeta, *http.Response, error) {
+	if vendor == "" || pkg == "" {
+		return nil, nil, fmt.Errorf("'package' and 'vendor' options are required for meta request")
+	}
+	if opts == nil {
+		opts = &MetaV2Options{}
+	}
+
+	template, err := c.metadataTemplate(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := vendor + "/" + pkg
+	if opts.Dev {
+		name += "~dev"
+	}
+	packagesURL, err := c.baseURL.Parse(strings.TrimSuffix(c.baseURL.Path, "/") + "/packages.json")
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to resolve metadata url: %w", err)
+	}
+	route, err := packagesURL.Parse(strings.ReplaceAll(template, "%package%", name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to resolve metadata url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", route.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	if !opts.ModifiedSince.IsZero() {
+		req.Header.Set("If-Modified-Since", opts.ModifiedSince.UTC().Format(http.TimeFormat))
+	}
+
+	var raw metaV2Response
+	var r *http.Response
+	if r, err = c.parseResponse(req, &raw); err != nil {
+		if err == ErrNotModified {
+			return nil, r, err
+		}
+		return nil, nil, err
+	}
+
+	pm, err := raw.expand()
+	if err != nil {
+		return nil, nil, err
+	}
+	return pm, r, nil
+}
+
+// metadataTemplate returns Composer v2 metadata URL template (discovered from 'packages.json').
+func (c PackagistClient) metadataTemplate(ctx context.Context) (string, error) {
+	if c.metadata != nil {
+		c.metadata.mu.Lock()
+		defer c.metadata.mu.Unlock()
+		if c.metadata.template != "" {
+			return c.metadata.template, nil
+		}
+	}
+
+	ri, _, err := c.Repository(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to discover metadata url: %w", err)
+	}
+	if ri.MetadataURL == "" {
+		return "", fmt.Errorf("repository doesn't support composer v2 metadata")
+	}
+	if c.metadata != nil {
+		c.metadata.template = ri.MetadataURL
+	}
+	return ri.MetadataURL, nil
+}
+
+// metaV2Response represents Composer v2 metadata response (versions are possibly minified).
+type metaV2Response struct {
+	Minified string                                  `json:"minified"`
+	Packages map[string][]map[string]json.RawMessage `json:"packages"`
+}
+
+// expand converts the response into PackagesMeta expanding minified versions.
+//
+// Minified versions contain only the fields changed since the previous version, removed fields are set to '__unset'.
+func (mr metaV2Response) expand() (*PackagesMeta, error) {
+	pm := &PackagesMeta{Packages: map[string]PackageMeta{}}
+	for name, versions := range mr.Packages {
+		meta := make(PackageMeta, 0, len(versions))
+		var current map[string]json.RawMessage
+		for _, version := range versions {
+			if mr.Minified == minifiedFormat && current != nil {
+				next := make(map[string]json.RawMessage, len(current))
+				for k, v := range current {
+					next[k] = v
+				}
+				for k, v := range version {
+					if string(v) == `"__unset"` {
+						delete(next, k)
+						continue
+					}
+					next[k] = v
+				}
+				version = next
+			}
+			current = version
+
+			b, err := json.Marshal(version)
+			if err != nil {
+				return nil, fmt.Errorf("unable to expand %q metadata: %w", name, err)
+			}
+			var vm VersionMeta
+			if err = json.Unmarshal(b, &vm); err != nil {
+				return nil, fmt.Errorf("unable to parse %q metadata: %w", name, err)
+			}
+			meta = append(meta, vm)
+		}
+		pm.Packages[name] = meta
+	}
+	return pm, nil
+}
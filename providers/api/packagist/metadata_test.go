@@ -0,0 +1,164 @@
+package packagist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dephub/dephub-core/providers/transport"
+)
+
+func TestMetaV2Method(t *testing.T) {
+	modified := time.Date(2021, 11, 2, 10, 0, 0, 0, time.UTC)
+	discoveries := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/composer/packages.json":
+			discoveries++
+			_, _ = rw.Write([]byte(`{"packages": [], "metadata-url": "/composer/p2/%package%.json", "available-packages": ["hello/world"]}`))
+		case "/composer/p2/hello/world.json":
+			if r.Header.Get("If-Modified-Since") == modified.Format(http.TimeFormat) {
+				rw.WriteHeader(http.StatusNotModified)
+				return
+			}
+			rw.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+			_, _ = rw.Write([]byte(`{"minified": "composer/2.0", "packages": {"hello/world": [
+				{"name": "hello/world", "version": "2.0.0", "version_normalized": "2.0.0.0", "description": "Hello",
+					"license": ["MIT"], "source": {"type": "git", "url": "https://github.com/hello/world.git", "reference": "b"}},
+				{"version": "1.1.0", "version_normalized": "1.1.0.0", "license": "__unset"},
+				{"version": "1.0.0", "version_normalized": "1.0.0.0", "description": "Old hello"}
+			]}}`))
+		case "/composer/p2/hello/world~dev.json":
+			_, _ = rw.Write([]byte(`{"packages": {"hello/world": [{"name": "hello/world", "version": "dev-main"}]}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL + "/composer")
+	cl, err := NewClient(srv.Client(), URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	meta, resp, err := cl.MetaV2(context.Background(), "hello", "world", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	versions := meta.Packages["hello/world"]
+	if len(versions) != 3 {
+		t.Fatalf("expected 3 expanded versions, got %+v", versions)
+	}
+	expected := []struct {
+		Version     string
+		Description string
+		License     []string
+	}{
+		{"2.0.0", "Hello", []string{"MIT"}},
+		{"1.1.0", "Hello", nil},
+		{"1.0.0", "Old hello", nil},
+	}
+	for i, exp := range expected {
+		v := versions[i]
+		if v.Name != "hello/world" || v.Version != exp.Version || v.Description != exp.Description ||
+			!reflect.DeepEqual(v.License, exp.License) || v.Source.URL != "https://github.com/hello/world.git" {
+			t.Errorf("unexpected expanded version #%d: %+v", i, v)
+		}
+	}
+	if resp.Header.Get("Last-Modified") == "" {
+		t.Errorf("expected response with Last-Modified header")
+	}
+
+	meta, resp, err = cl.MetaV2(context.Background(), "hello", "world", &MetaV2Options{ModifiedSince: modified})
+	if err != ErrNotModified || meta != nil || resp == nil || resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected not modified error, got: %+v, %v", meta, err)
+	}
+
+	meta, _, err = cl.MetaV2(context.Background(), "hello", "world", &MetaV2Options{Dev: true})
+	if err != nil || len(meta.Packages["hello/world"]) != 1 || meta.Packages["hello/world"][0].Version != "dev-main" {
+		t.Errorf("unexpected dev versions: %+v, %v", meta, err)
+	}
+
+	if discoveries != 1 {
+		t.Errorf("expected metadata url to be discovered once, got %d requests", discoveries)
+	}
+}
+
+func TestMetaV2Method_CachingClient(t *testing.T) {
+	modified := time.Date(2021, 11, 2, 10, 0, 0, 0, time.UTC)
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/composer/packages.json":
+			rw.Header().Set("Cache-Control", "max-age=300")
+			_, _ = rw.Write([]byte(`{"packages": [], "metadata-url": "/composer/p2/%package%.json"}`))
+		case "/composer/p2/hello/world.json":
+			requests++
+			if r.Header.Get("If-Modified-Since") == modified.Format(http.TimeFormat) {
+				rw.WriteHeader(http.StatusNotModified)
+				return
+			}
+			rw.Header().Set("Cache-Control", "max-age=300")
+			rw.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+			_, _ = rw.Write([]byte(`{"packages": {"hello/world": [{"name": "hello/world", "version": "2.0.0"}]}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL + "/composer")
+	cl, err := NewClient(transport.NewCachingClient(srv.Client(), nil), URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	meta, _, err := cl.MetaV2(context.Background(), "hello", "world", nil)
+	if err != nil || len(meta.Packages["hello/world"]) != 1 {
+		t.Fatalf("unexpected metadata: %+v, %v", meta, err)
+	}
+
+	// Fresh cached response must not hide the caller's conditional request result
+	meta, resp, err := cl.MetaV2(context.Background(), "hello", "world", &MetaV2Options{ModifiedSince: modified})
+	if err != ErrNotModified || meta != nil || resp == nil || resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected not modified error, got: %+v, %v", meta, err)
+	}
+	if requests != 2 {
+		t.Errorf("expected conditional request to be sent, got %d requests", requests)
+	}
+
+	meta, _, err = cl.MetaV2(context.Background(), "hello", "world", nil)
+	if err != nil || len(meta.Packages["hello/world"]) != 1 || requests != 2 {
+		t.Errorf("expected cached metadata, got: %+v, %v (%d requests)", meta, err, requests)
+	}
+}
+
+func TestMetaV2Method_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/packages.json" {
+			_, _ = rw.Write([]byte(`{"packages": [], "providers-url": "/p/%package%$%hash%.json"}`))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	cl := getTestingClient(t, srv)
+
+	if _, _, err := cl.MetaV2(context.Background(), "", "world", nil); err == nil {
+		t.Errorf("expected error on empty vendor, got none")
+	}
+	_, _, err := cl.MetaV2(context.Background(), "hello", "world", nil)
+	if err == nil || err.Error() != "repository doesn't support composer v2 metadata" {
+		t.Errorf("expected composer v1 repository error, got: %v", err)
+	}
+
+	info, _, err := cl.Repository(context.Background())
+	if err != nil || info.ProvidersURL != "/p/%package%$%hash%.json" {
+		t.Errorf("unexpected repository info: %+v, %v", info, err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dephub/dephub-core/providers/transport"
 	"github.com/google/go-querystring/query"
 )
 
@@ -59,12 +60,25 @@ type Client interface {
 
 	// Stats method returns simple global packagist statistics.
 	Stats(ctx context.Context) (*PackagesStats, *http.Response, error)
+
+	// Repository method returns the repository 'packages.json' information (e.g. Composer v2 metadata URL template).
+	Repository(ctx context.Context) (*RepositoryInfo, *http.Response, error)
+
+	// MetaV2 method is used to get package metadata with Composer v2 metadata protocol (minified responses are expanded).
+	//
+	// It works with any Composer v2 repository, metadata URL template is discovered from 'packages.json'.
+	MetaV2(ctx context.Context, vendor, pkg string, opts *MetaV2Options) (*PackagesMeta, *http.Response, error)
+
+	// Changes method returns packages metadata changes since the cursor (previous response timestamp).
+	Changes(ctx context.Context, since int64) (*MetadataChanges, *http.Response, error)
 }
 
 // PackagistClient is used to send API requests to package repository
 type PackagistClient struct {
 	baseURL    url.URL
 	HttpClient *http.Client
+	// metadata - discovered Composer v2 metadata URL template (shared between client copies)
+	metadata *metadataURL
 }
 
 // NewClient creates and returns a new client
@@ -85,7 +99,7 @@ func NewClient(httpClient *http.Client, URL *url.URL) (Client, error) {
 		httpClient = http.DefaultClient
 	}
 
-	return &PackagistClient{baseURL: *URL, HttpClient: httpClient}, nil
+	return &PackagistClient{baseURL: *URL, HttpClient: httpClient, metadata: &metadataURL{}}, nil
 }
 
 // PackagesList represents list of packages.
@@ -257,9 +271,40 @@ func (pms *PackageMeta) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Abandoned represents package 'abandoned' flag, it's either boolean or the suggested replacement package name.
+type Abandoned struct {
+	Abandoned bool
+	// Replacement is the suggested replacement package name (if any).
+	Replacement string
+}
+
+// UnmarshalJSON is used to decode both boolean and string 'abandoned' values.
+func (a *Abandoned) UnmarshalJSON(data []byte) error {
+	var replacement string
+	if err := json.Unmarshal(data, &replacement); err == nil {
+		*a = Abandoned{Abandoned: true, Replacement: replacement}
+		return nil
+	}
+	var abandoned bool
+	if err := json.Unmarshal(data, &abandoned); err != nil && string(data) != "null" {
+		return fmt.Errorf("unable to parse abandoned flag: %w", err)
+	}
+	*a = Abandoned{Abandoned: abandoned}
+	return nil
+}
+
+// MarshalJSON encodes the flag in the original format.
+func (a Abandoned) MarshalJSON() ([]byte, error) {
+	if a.Replacement != "" {
+		return json.Marshal(a.Replacement)
+	}
+	return json.Marshal(a.Abandoned)
+}
+
 // VersionMeta represents versions container.
 type VersionMeta struct {
-	Authors []struct {
+	Abandoned Abandoned `json:"abandoned"`
+	Authors   []struct {
 		Email string `json:"email"`
 		Name  string `json:"name"`
 	} `json:"authors"`
@@ -341,7 +386,8 @@ type PackageData struct {
 			Monthly int `json:"monthly"`
 			Daily   int `json:"daily"`
 		} `json:"downloads"`
-		Favers int `json:"favers"`
+		Favers    int       `json:"favers"`
+		Abandoned Abandoned `json:"abandoned"`
 	} `json:"package"`
 }
 
@@ -480,6 +526,19 @@ func (c PackagistClient) SecAdvisories(ctx context.Context, packages []string) (
 	return &pl, r, nil
 }
 
+// responseError represents packagist HTTP error response, 404 responses match ErrNotFound.
+type responseError struct {
+	StatusCode int
+}
+
+func (e *responseError) Error() string {
+	return fmt.Sprintf("packagist responded with HTTP error '%d: %s'", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *responseError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
 // errorResponse represents packagist error response
 type errorResponse struct {
 	Status  string `json:"status"`
@@ -493,8 +552,14 @@ func (c *PackagistClient) parseResponse(req *http.Request, dt interface{}) (r *h
 	}
 	defer r.Body.Close()
 
+	if err = transport.CheckRateLimit(r); err != nil {
+		return nil, err
+	}
+	if r.StatusCode == http.StatusNotModified {
+		return r, ErrNotModified
+	}
 	if r.StatusCode >= 400 {
-		return nil, fmt.Errorf("packagist responded with HTTP error '%d: %s'", r.StatusCode, http.StatusText(r.StatusCode))
+		return nil, &responseError{StatusCode: r.StatusCode}
 	}
 
 	body, err := ioutil.ReadAll(r.Body)
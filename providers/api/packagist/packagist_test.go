@@ -2,11 +2,16 @@ package packagist
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/dephub/dephub-core/providers/transport"
 )
 
 func getTestingClient(t *testing.T, srv *httptest.Server) Client {
@@ -111,6 +116,20 @@ func TestHttpErrorResponse(t *testing.T) {
 	}
 }
 
+func TestRateLimitErrorResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "30")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	cl := getTestingClient(t, srv)
+
+	_, _, err := cl.Meta(context.Background(), "laravel", "framework")
+	var rle *transport.RateLimitError
+	if !errors.As(err, &rle) || time.Until(rle.Reset) <= 0 {
+		t.Errorf("expected rate limit error with reset time, got: %v", err)
+	}
+}
+
 func TestReqErrorResponse(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) { _, _ = rw.Write([]byte("Hello world!")) }))
 	cl := getTestingClient(t, srv)
@@ -861,3 +880,35 @@ func TestAffectedVersionsNormalizeMethod(t *testing.T) {
 		t.Errorf("affected versions normalization is incorrect, expected '%s', got '%s", expected, normalized)
 	}
 }
+
+func TestAbandonedUnmarshal(t *testing.T) {
+	var meta PackagesMeta
+	err := json.Unmarshal([]byte(`{"packages": {"hello/world": {
+		"1.0.0": {"version": "1.0.0"},
+		"1.1.0": {"version": "1.1.0", "abandoned": true},
+		"1.2.0": {"version": "1.2.0", "abandoned": "hello/universe"},
+		"1.3.0": {"version": "1.3.0", "abandoned": false}
+	}}}`), &meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Abandoned{{}, {Abandoned: true}, {Abandoned: true, Replacement: "hello/universe"}, {}}
+	for i, v := range meta.Packages["hello/world"] {
+		if v.Abandoned != expected[i] {
+			t.Errorf("unexpected %s abandoned flag: %+v", v.Version, v.Abandoned)
+		}
+	}
+
+	var data PackageData
+	if err = json.Unmarshal([]byte(`{"package": {"name": "hello/world", "abandoned": "hello/universe"}}`), &data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.Package.Abandoned.Replacement != "hello/universe" {
+		t.Errorf("unexpected package abandoned flag: %+v", data.Package.Abandoned)
+	}
+
+	if err = json.Unmarshal([]byte(`{"abandoned": 1}`), &VersionMeta{}); err == nil {
+		t.Errorf("expected invalid abandoned flag error")
+	}
+}
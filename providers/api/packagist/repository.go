@@ -0,0 +1,384 @@
+package packagist
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dephub/dephub-core/providers/transport"
+)
+
+// ErrNotFound is returned when the package doesn't exist in the repository (or in any chained repository).
+var ErrNotFound = errors.New("package not found")
+
+// MetaClient interface defines package metadata providers: Packagist, Composer repositories or their chain.
+type MetaClient interface {
+	// Meta methods is used to get package versions metadata.
+	Meta(ctx context.Context, vendor, pkg string) (*PackagesMeta, *http.Response, error)
+}
+
+// RepositoryOptions represents Composer repository authentication options.
+type RepositoryOptions struct {
+	// Username and Password are used for HTTP basic authentication (e.g. Private Packagist token is the password).
+	Username string
+	Password string
+	// Token is used for HTTP bearer authentication.
+	Token string
+}
+
+// RepositoryClient represents Composer repository (Satis, Private Packagist, etc.) client.
+//
+// Repository 'packages.json' is loaded once, packages are looked up in it's inline packages, 'includes' files,
+// Composer v2 metadata ('metadata-url') and Composer v1 providers ('provider-includes' and 'providers-url').
+type RepositoryClient struct {
+	client *PackagistClient
+
+	mu    sync.Mutex
+	index *repositoryIndex
+}
+
+// repositoryIndex represents loaded repository 'packages.json'.
+type repositoryIndex struct {
+	packagesURL *url.URL
+	info        RepositoryInfo
+	// packages - inline and included packages
+	packages map[string]PackageMeta
+	// providers - Composer v1 providers hashes by the package name
+	providers map[string]string
+}
+
+// repositoryFile represents Composer repository 'packages.json' and included files.
+type repositoryFile struct {
+	RepositoryInfo
+	Packages         json.RawMessage            `json:"packages"`
+	Includes         map[string]json.RawMessage `json:"includes"`
+	ProviderIncludes map[string]struct {
+		Sha256 string `json:"sha256"`
+	} `json:"provider-includes"`
+	Providers map[string]struct {
+		Sha256 string `json:"sha256"`
+	} `json:"providers"`
+}
+
+// NewRepositoryClient constructs Composer repository client, URL is the repository address ('packages.json' location).
+//
+// If httpClient is nil - default value will be used. Credentials (if any) are sent only to the repository host.
+func NewRepositoryClient(httpClient *http.Client, URL *url.URL, opts *RepositoryOptions) (*RepositoryClient, error) {
+	if URL == nil {
+		return nil, fmt.Errorf("repository url is required")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if opts != nil && (opts.Username != "" || opts.Password != "" || opts.Token != "") {
+		httpClient = transport.NewAuthClient(httpClient, URL.Host, transport.Credentials{Username: opts.Username, Password: opts.Password, Token: opts.Token})
+	}
+	base := *URL
+	base.Path = strings.TrimSuffix(strings.TrimSuffix(base.Path, "/packages.json"), "/")
+	return &RepositoryClient{client: &PackagistClient{baseURL: base, HttpClient: httpClient, metadata: &metadataURL{}}}, nil
+}
+
+// Meta method is used to get package versions metadata, versions are ordered from the oldest to the newest
+// (as Packagist Meta() responses), ErrNotFound is returned (wrapped) if the repository doesn't provide the package.
+func (rc *RepositoryClient) Meta(ctx context.Context, vendor, pkg string) (*PackagesMeta, *http.Response, error) {
+	if vendor == "" || pkg == "" {
+		return nil, nil, fmt.Errorf("'package' and 'vendor' options are required for meta request")
+	}
+	name := vendor + "/" + pkg
+
+	idx, err := rc.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta, ok := idx.packages[name]; ok {
+		return &PackagesMeta{Packages: map[string]PackageMeta{name: meta}}, nil, nil
+	}
+	if idx.info.MetadataURL != "" && idx.provides(name) {
+		return rc.metaV2(ctx, vendor, pkg)
+	}
+	if hash, ok := idx.providers[name]; ok && idx.info.ProvidersURL != "" {
+		route := strings.NewReplacer("%package%", name, "%hash%", hash).Replace(idx.info.ProvidersURL)
+		var file repositoryFile
+		r, err := rc.file(ctx, idx.packagesURL, route, &file)
+		if err != nil {
+			return nil, nil, err
+		}
+		packages, err := file.packages()
+		if err != nil {
+			return nil, nil, err
+		}
+		return &PackagesMeta{Packages: packages}, r, nil
+	}
+	return nil, nil, fmt.Errorf("%w: '%s'", ErrNotFound, name)
+}
+
+// metaV2 - helper to get both tagged and development versions with Composer v2 metadata protocol.
+func (rc *RepositoryClient) metaV2(ctx context.Context, vendor, pkg string) (*PackagesMeta, *http.Response, error) {
+	name := vendor + "/" + pkg
+	meta, r, err := rc.client.MetaV2(ctx, vendor, pkg, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil, fmt.Errorf("%w: '%s'", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	dev, _, err := rc.client.MetaV2(ctx, vendor, pkg, &MetaV2Options{Dev: true})
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, nil, err
+	}
+
+	// Versions are served from the newest to the oldest, development versions go first as in Composer v1 metadata
+	var versions PackageMeta
+	if dev != nil {
+		versions = append(versions, reverseVersions(dev.Packages[name])...)
+	}
+	versions = append(versions, reverseVersions(meta.Packages[name])...)
+	return &PackagesMeta{Packages: map[string]PackageMeta{name: versions}}, r, nil
+}
+
+// load loads the repository 'packages.json' (once per client) with it's included files.
+func (rc *RepositoryClient) load(ctx context.Context) (*repositoryIndex, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.index != nil {
+		return rc.index, nil
+	}
+
+	packagesURL, err := rc.client.baseURL.Parse(rc.client.baseURL.Path + "/packages.json")
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve repository url: %w", err)
+	}
+	var root repositoryFile
+	if _, err = rc.file(ctx, packagesURL, packagesURL.String(), &root); err != nil {
+		return nil, fmt.Errorf("unable to load repository packages.json: %w", err)
+	}
+
+	idx := &repositoryIndex{packagesURL: packagesURL, info: root.RepositoryInfo, providers: map[string]string{}}
+	if idx.packages, err = root.packages(); err != nil {
+		return nil, err
+	}
+
+	includes := make([]string, 0, len(root.Includes))
+	for include := range root.Includes {
+		includes = append(includes, include)
+	}
+	sort.Strings(includes)
+	for _, include := range includes {
+		var file repositoryFile
+		if _, err = rc.file(ctx, packagesURL, include, &file); err != nil {
+			return nil, fmt.Errorf("unable to load repository include: %w", err)
+		}
+		packages, err := file.packages()
+		if err != nil {
+			return nil, err
+		}
+		for name, meta := range packages {
+			idx.packages[name] = append(idx.packages[name], meta...)
+		}
+	}
+
+	for include, hash := range root.ProviderIncludes {
+		var file repositoryFile
+		route := strings.ReplaceAll(include, "%hash%", hash.Sha256)
+		if _, err = rc.file(ctx, packagesURL, route, &file); err != nil {
+			return nil, fmt.Errorf("unable to load repository provider include: %w", err)
+		}
+		for name, provider := range file.Providers {
+			idx.providers[name] = provider.Sha256
+		}
+	}
+
+	if root.MetadataURL != "" {
+		rc.client.metadata.mu.Lock()
+		rc.client.metadata.template = root.MetadataURL
+		rc.client.metadata.mu.Unlock()
+	}
+	rc.index = idx
+	return idx, nil
+}
+
+// file - helper to load the repository JSON file, route is resolved relatively to 'packages.json' location.
+func (rc *RepositoryClient) file(ctx context.Context, packagesURL *url.URL, route string, v interface{}) (*http.Response, error) {
+	u, err := packagesURL.Parse(route)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve repository file url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	return rc.client.parseResponse(req, v)
+}
+
+// provides reports whether the package may be provided by the repository ('available-packages' and their patterns).
+func (idx *repositoryIndex) provides(name string) bool {
+	if len(idx.info.AvailablePackages) == 0 && len(idx.info.AvailablePackagePatterns) == 0 {
+		return true
+	}
+	for _, available := range idx.info.AvailablePackages {
+		if available == name {
+			return true
+		}
+	}
+	for _, pattern := range idx.info.AvailablePackagePatterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// packages returns the file packages, empty JSON array (empty PHP array) means no packages.
+func (rf repositoryFile) packages() (map[string]PackageMeta, error) {
+	packages := map[string]PackageMeta{}
+	if len(rf.Packages) == 0 || rf.Packages[0] != '{' {
+		return packages, nil
+	}
+	if err := json.Unmarshal(rf.Packages, &packages); err != nil {
+		return nil, fmt.Errorf("unable to parse repository packages: %w", err)
+	}
+	return packages, nil
+}
+
+// reverseVersions - helper to reverse the versions order.
+func reverseVersions(versions PackageMeta) PackageMeta {
+	res := make(PackageMeta, len(versions))
+	for i, v := range versions {
+		res[len(versions)-1-i] = v
+	}
+	return res
+}
+
+// NewArtifactRepository constructs Composer artifact repository reading packages from the directory zip archives.
+//
+// Every archive must contain 'composer.json' (in the root or in the top-level directory) with 'name' and 'version' fields.
+func NewArtifactRepository(dir string) MetaClient {
+	return &ArtifactRepository{Dir: dir}
+}
+
+// ArtifactRepository represents Composer artifact repository (directory of zip archives), archives are read once.
+type ArtifactRepository struct {
+	Dir string
+
+	mu       sync.Mutex
+	packages map[string]PackageMeta
+}
+
+// Meta method is used to get package versions metadata, versions are ordered from the oldest to the newest.
+func (ar *ArtifactRepository) Meta(ctx context.Context, vendor, pkg string) (*PackagesMeta, *http.Response, error) {
+	if vendor == "" || pkg == "" {
+		return nil, nil, fmt.Errorf("'package' and 'vendor' options are required for meta request")
+	}
+	name := vendor + "/" + pkg
+
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	if ar.packages == nil {
+		packages, err := readArtifacts(ar.Dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		ar.packages = packages
+	}
+	meta, ok := ar.packages[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: '%s'", ErrNotFound, name)
+	}
+	return &PackagesMeta{Packages: map[string]PackageMeta{name: meta}}, nil, nil
+}
+
+// readArtifacts - helper to read packages versions from the directory zip archives.
+func readArtifacts(dir string) (map[string]PackageMeta, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read artifacts directory: %w", err)
+	}
+
+	packages := map[string]PackageMeta{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".zip") {
+			continue
+		}
+		name := filepath.Join(dir, entry.Name())
+		b, err := artifactComposerJSON(name)
+		if err != nil {
+			return nil, err
+		}
+		var v VersionMeta
+		if err = json.Unmarshal(b, &v); err != nil {
+			return nil, fmt.Errorf("unable to parse '%s' artifact composer.json: %w", entry.Name(), err)
+		}
+		if v.Name == "" || v.Version == "" {
+			return nil, fmt.Errorf("'%s' artifact composer.json must contain name and version", entry.Name())
+		}
+		v.Dist.Type, v.Dist.URL = "zip", name
+		packages[v.Name] = append(packages[v.Name], v)
+	}
+	return packages, nil
+}
+
+// artifactComposerJSON - helper to read 'composer.json' from the artifact archive.
+func artifactComposerJSON(name string) ([]byte, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s' artifact: %w", filepath.Base(name), err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		clean := path.Clean(f.Name)
+		if clean != "composer.json" && (path.Base(clean) != "composer.json" || strings.Count(clean, "/") != 1) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read '%s' artifact: %w", filepath.Base(name), err)
+		}
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read '%s' artifact: %w", filepath.Base(name), err)
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("'%s' artifact doesn't contain composer.json", filepath.Base(name))
+}
+
+// NewRepositoryChain constructs MetaClient looking up packages in the repositories in priority order (as Composer does):
+// the first repository providing the package is used, the following ones are not requested.
+func NewRepositoryChain(repos ...MetaClient) MetaClient {
+	return RepositoryChain(repos)
+}
+
+// RepositoryChain represents Composer repositories list in priority order.
+type RepositoryChain []MetaClient
+
+// Meta method returns package versions metadata from the first repository providing it.
+func (chain RepositoryChain) Meta(ctx context.Context, vendor, pkg string) (*PackagesMeta, *http.Response, error) {
+	name := vendor + "/" + pkg
+	for _, repo := range chain {
+		meta, r, err := repo.Meta(ctx, vendor, pkg)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := meta.Packages[name]; ok {
+			return meta, r, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: '%s'", ErrNotFound, name)
+}
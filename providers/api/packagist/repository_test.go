@@ -0,0 +1,249 @@
+package packagist
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// repositoryDir - helper to create static Composer repository directory.
+func repositoryDir(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "composer-repository")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatalf("unable to create dir: %v", err)
+		}
+		if err = ioutil.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatalf("unable to write file: %v", err)
+		}
+	}
+	return dir
+}
+
+// versions - helper to get the package versions list.
+func versions(meta PackageMeta) []string {
+	res := make([]string, 0, len(meta))
+	for _, v := range meta {
+		res = append(res, v.Version)
+	}
+	return res
+}
+
+func TestRepositoryClient_Meta(t *testing.T) {
+	providers := `{"providers": {"legacy/pkg": {"sha256": "abc"}}}`
+	sum := sha256.Sum256([]byte(providers))
+	hash := hex.EncodeToString(sum[:])
+
+	dir := repositoryDir(t, map[string]string{
+		"satis/packages.json": `{
+			"packages": {"inline/pkg": {"1.0.0": {"name": "inline/pkg", "version": "1.0.0"}}},
+			"includes": {"include/all$1.json": {"sha1": "x"}},
+			"provider-includes": {"p/provider-all$%hash%.json": {"sha256": "` + hash + `"}},
+			"providers-url": "/satis/p/%package%$%hash%.json",
+			"metadata-url": "/satis/p2/%package%.json",
+			"available-packages": ["modern/pkg"]
+		}`,
+		"satis/include/all$1.json": `{"packages": {"included/pkg": {
+			"1.0.0": {"name": "included/pkg", "version": "1.0.0"}
+		}}}`,
+		"satis/p/provider-all$" + hash + ".json": providers,
+		"satis/p/legacy/pkg$abc.json":            `{"packages": {"legacy/pkg": {"2.0.0": {"name": "legacy/pkg", "version": "2.0.0"}}}}`,
+		"satis/p2/modern/pkg.json": `{"minified": "composer/2.0", "packages": {"modern/pkg": [
+			{"name": "modern/pkg", "version": "2.0.0"}, {"version": "1.0.0"}
+		]}}`,
+		"satis/p2/modern/pkg~dev.json": `{"packages": {"modern/pkg": [{"name": "modern/pkg", "version": "dev-main"}]}}`,
+	})
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL + "/satis/packages.json")
+	repo, err := NewRepositoryClient(srv.Client(), URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		Name     string
+		Versions []string
+	}{
+		{"inline/pkg", []string{"1.0.0"}},
+		{"included/pkg", []string{"1.0.0"}},
+		{"legacy/pkg", []string{"2.0.0"}},
+		{"modern/pkg", []string{"dev-main", "1.0.0", "2.0.0"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			vendor, pkg := filepath.Split(c.Name)
+			meta, _, err := repo.Meta(context.Background(), filepath.Clean(vendor), pkg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := versions(meta.Packages[c.Name])
+			if len(got) != len(c.Versions) {
+				t.Fatalf("expected %v versions, got %v", c.Versions, got)
+			}
+			for i := range got {
+				if got[i] != c.Versions[i] {
+					t.Fatalf("expected %v versions, got %v", c.Versions, got)
+				}
+			}
+		})
+	}
+
+	_, _, err = repo.Meta(context.Background(), "missing", "pkg")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRepositoryClient_Auth(t *testing.T) {
+	dir := repositoryDir(t, map[string]string{
+		"packages.json": `{"packages": {"private/pkg": {"1.0.0": {"name": "private/pkg", "version": "1.0.0"}}}}`,
+	})
+	files := http.FileServer(http.Dir(dir))
+
+	cases := []struct {
+		Name string
+		Opts *RepositoryOptions
+		Auth func(r *http.Request) bool
+		Err  bool
+	}{
+		{
+			Name: "basic",
+			Opts: &RepositoryOptions{Username: "user", Password: "token"},
+			Auth: func(r *http.Request) bool {
+				user, pass, ok := r.BasicAuth()
+				return ok && user == "user" && pass == "token"
+			},
+		},
+		{
+			Name: "bearer",
+			Opts: &RepositoryOptions{Token: "secret"},
+			Auth: func(r *http.Request) bool { return r.Header.Get("Authorization") == "Bearer secret" },
+		},
+		{
+			Name: "missing credentials",
+			Auth: func(r *http.Request) bool { return r.Header.Get("Authorization") != "" },
+			Err:  true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				if !c.Auth(r) {
+					rw.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				files.ServeHTTP(rw, r)
+			}))
+			defer srv.Close()
+
+			URL, _ := url.Parse(srv.URL)
+			repo, err := NewRepositoryClient(srv.Client(), URL, c.Opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			meta, _, err := repo.Meta(context.Background(), "private", "pkg")
+			if c.Err {
+				if err == nil || errors.Is(err, ErrNotFound) {
+					t.Fatalf("expected authentication error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(meta.Packages["private/pkg"]) != 1 {
+				t.Fatalf("unexpected meta: %+v", meta)
+			}
+		})
+	}
+}
+
+func TestRepositoryChain_Meta(t *testing.T) {
+	private := repositoryDir(t, map[string]string{
+		"packages.json": `{"packages": {"shared/pkg": {"1.0.0-private": {"name": "shared/pkg", "version": "1.0.0-private"}}}}`,
+	})
+	public := repositoryDir(t, map[string]string{
+		"packages.json": `{"packages": {
+			"shared/pkg": {"2.0.0": {"name": "shared/pkg", "version": "2.0.0"}},
+			"public/pkg": {"1.0.0": {"name": "public/pkg", "version": "1.0.0"}}
+		}}`,
+	})
+	privateSrv := httptest.NewServer(http.FileServer(http.Dir(private)))
+	defer privateSrv.Close()
+	publicSrv := httptest.NewServer(http.FileServer(http.Dir(public)))
+	defer publicSrv.Close()
+
+	privateURL, _ := url.Parse(privateSrv.URL)
+	publicURL, _ := url.Parse(publicSrv.URL)
+	privateRepo, _ := NewRepositoryClient(privateSrv.Client(), privateURL, nil)
+	publicRepo, _ := NewRepositoryClient(publicSrv.Client(), publicURL, nil)
+	chain := NewRepositoryChain(privateRepo, publicRepo)
+
+	meta, _, err := chain.Meta(context.Background(), "shared", "pkg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := versions(meta.Packages["shared/pkg"]); len(got) != 1 || got[0] != "1.0.0-private" {
+		t.Fatalf("expected the first repository versions, got %v", got)
+	}
+
+	meta, _, err = chain.Meta(context.Background(), "public", "pkg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := versions(meta.Packages["public/pkg"]); len(got) != 1 || got[0] != "1.0.0" {
+		t.Fatalf("expected the second repository versions, got %v", got)
+	}
+
+	if _, _, err = chain.Meta(context.Background(), "missing", "pkg"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestArtifactRepository_Meta(t *testing.T) {
+	dir := repositoryDir(t, nil)
+	archives := map[string]string{
+		"pkg-1.0.0.zip": "composer.json",
+		"pkg-1.1.0.zip": "pkg-1.1.0/composer.json",
+	}
+	for name, path := range archives {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("unable to create archive: %v", err)
+		}
+		zw := zip.NewWriter(f)
+		w, _ := zw.Create(path)
+		version := name[4 : len(name)-4]
+		_, _ = w.Write([]byte(`{"name": "artifact/pkg", "version": "` + version + `"}`))
+		_ = zw.Close()
+		_ = f.Close()
+	}
+
+	repo := NewArtifactRepository(dir)
+	meta, _, err := repo.Meta(context.Background(), "artifact", "pkg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := versions(meta.Packages["artifact/pkg"]); len(got) != 2 || got[0] != "1.0.0" || got[1] != "1.1.0" {
+		t.Fatalf("unexpected versions: %v", got)
+	}
+	if _, _, err = repo.Meta(context.Background(), "missing", "pkg"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
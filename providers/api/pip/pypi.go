@@ -15,6 +15,9 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/dephub/dephub-core/providers/transport"
+	"github.com/dephub/dephub-core/providers/versioneer"
 )
 
 // pyPiBaseURL - PyPi base API url (used as default client baseURL)
@@ -80,11 +83,12 @@ func (pc PyPiClient) Release(ctx context.Context, name, version string) (*PipPac
 		return nil, nil, fmt.Errorf("pacakge name is required and can't be empty")
 	}
 
+	// PyPi redirects to the canonical name route, so request it directly
 	var path string
 	if version == "" {
-		path = fmt.Sprintf("%s/pypi/%s/json", &pc.baseUrl, name)
+		path = fmt.Sprintf("%s/pypi/%s/json", &pc.baseUrl, versioneer.NormalizePipName(name))
 	} else {
-		path = fmt.Sprintf("%s/pypi/%s/%s/json", &pc.baseUrl, name, version)
+		path = fmt.Sprintf("%s/pypi/%s/%s/json", &pc.baseUrl, versioneer.NormalizePipName(name), version)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
@@ -96,6 +100,12 @@ func (pc PyPiClient) Release(ctx context.Context, name, version string) (*PipPac
 		return nil, nil, fmt.Errorf("unable to send the request: %w", err)
 	}
 	defer resp.Body.Close()
+	if err = transport.CheckRateLimit(resp); err != nil {
+		return nil, resp, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, resp, fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
 	if resp.StatusCode != 200 {
 		return nil, resp, fmt.Errorf("Pypi returned with !=200 status code")
 	}
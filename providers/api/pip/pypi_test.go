@@ -3,11 +3,14 @@ package pip
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
+
+	"github.com/dephub/dephub-core/providers/transport"
 )
 
 func TestPyPiNewClientMethod(t *testing.T) {
@@ -38,7 +41,7 @@ func TestPyPiNewClientMethod(t *testing.T) {
 
 func TestPyPiClientPackageMethod(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		expectedPath := "/pypi/package_name/json"
+		expectedPath := "/pypi/package-name/json"
 		if r.URL.Path != expectedPath {
 			t.Errorf("expected url call is %q, got %q", r.URL.Path, expectedPath)
 		}
@@ -65,7 +68,7 @@ func TestPyPiClientPackageMethod(t *testing.T) {
 
 func TestPyPiClientReleaseMethod(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		expectedPath := "/pypi/package_name/package_version/json"
+		expectedPath := "/pypi/package-name/package_version/json"
 		if r.URL.Path != expectedPath {
 			t.Errorf("expected url call is %q, got %q", r.URL.Path, expectedPath)
 		}
@@ -126,6 +129,23 @@ func TestPyPiClientRelease_Errors(t *testing.T) {
 			}
 		})
 	}
+
+	URL, _ := url.Parse(notFoundSrv.URL)
+	if _, _, err := NewPyPiClient(notFoundSrv.Client(), URL).Package(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestPyPiClientRelease_RateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	URL, _ := url.Parse(srv.URL)
+
+	_, resp, err := NewPyPiClient(srv.Client(), URL).Package(context.Background(), "Django")
+	if !errors.Is(err, transport.ErrRateLimited) || resp == nil {
+		t.Errorf("expected rate limit error with the response, got: %v", err)
+	}
 }
 
 var sampleProjectJson = `{
@@ -0,0 +1,217 @@
+package pip
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var simpleHTMLPage = `<!DOCTYPE html>
+<html>
+  <body>
+    <h1>Links for my-package</h1>
+    <a href="../../packages/my_package-1.0.0.tar.gz#sha256=aaa">my_package-1.0.0.tar.gz</a><br/>
+    <a href="../../packages/my_package-1.0.0-py3-none-any.whl#sha256=bbb" data-requires-python="&gt;=3.6">my_package-1.0.0-py3-none-any.whl</a><br/>
+    <a href="https://files.example.com/my_package-1.10.0-py3-none-any.whl" data-requires-python="&gt;=3.8" data-yanked="">my_package-1.10.0-py3-none-any.whl</a><br/>
+    <a href='../../packages/My.Package-1.2.0rc1.zip' data-yanked="broken build">My.Package-1.2.0rc1.zip</a><br/>
+    <a href="../../packages/my_package-1.9.0.tar.gz">my_package-1.9.0.tar.gz</a><br/>
+  </body>
+</html>`
+
+var simpleJSONPage = `{
+	"meta": {"api-version": "1.1"},
+	"name": "my-package",
+	"files": [
+		{"filename": "my_package-1.0.0.tar.gz", "url": "/packages/my_package-1.0.0.tar.gz", "hashes": {"sha256": "aaa"}},
+		{"filename": "my_package-2.0.0-py3-none-any.whl", "url": "https://files.example.com/my_package-2.0.0-py3-none-any.whl",
+			"hashes": {}, "requires-python": ">=3.8", "yanked": "security issue"},
+		{"filename": "my_package-1.5.0-py3-none-any.whl", "url": "/packages/my_package-1.5.0-py3-none-any.whl",
+			"hashes": {"sha256": "ccc"}, "requires-python": ">=3.7", "yanked": false}
+	],
+	"versions": ["1.0.0", "1.5.0", "2.0.0", "3.0.0"]
+}`
+
+func TestSimpleClientReleaseMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != simpleAccept {
+			t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+		}
+		switch r.URL.Path {
+		case "/html/simple/my-package/":
+			rw.Header().Set("Content-Type", "text/html")
+			_, _ = rw.Write([]byte(simpleHTMLPage))
+		case "/json/simple/my-package/":
+			rw.Header().Set("Content-Type", SimpleJSONContentType)
+			_, _ = rw.Write([]byte(simpleJSONPage))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		Name     string
+		Index    string
+		Versions []string
+		Latest   string
+		Files    map[string][]PipPackageRelease
+	}{
+		{
+			Name:     "html",
+			Index:    "/html/simple/",
+			Versions: []string{"1.0.0", "1.2.0rc1", "1.9.0", "1.10.0"},
+			Latest:   "1.9.0",
+			Files: map[string][]PipPackageRelease{
+				"1.0.0": {
+					{BaseVersion: "1.0.0", Filename: "my_package-1.0.0.tar.gz", Packagetype: "sdist", URL: srv.URL + "/html/packages/my_package-1.0.0.tar.gz"},
+					{BaseVersion: "1.0.0", Filename: "my_package-1.0.0-py3-none-any.whl", Packagetype: "bdist_wheel", RequiresPython: ">=3.6",
+						URL: srv.URL + "/html/packages/my_package-1.0.0-py3-none-any.whl"},
+				},
+				"1.2.0rc1": {
+					{BaseVersion: "1.2.0rc1", Filename: "My.Package-1.2.0rc1.zip", Packagetype: "sdist", Yanked: true, YankedReason: "broken build",
+						URL: srv.URL + "/html/packages/My.Package-1.2.0rc1.zip"},
+				},
+				"1.10.0": {
+					{BaseVersion: "1.10.0", Filename: "my_package-1.10.0-py3-none-any.whl", Packagetype: "bdist_wheel", RequiresPython: ">=3.8", Yanked: true,
+						URL: "https://files.example.com/my_package-1.10.0-py3-none-any.whl"},
+				},
+			},
+		},
+		{
+			Name:     "json",
+			Index:    "/json/simple",
+			Versions: []string{"1.0.0", "1.5.0", "2.0.0", "3.0.0"},
+			Latest:   "3.0.0",
+			Files: map[string][]PipPackageRelease{
+				"1.5.0": {
+					{BaseVersion: "1.5.0", Filename: "my_package-1.5.0-py3-none-any.whl", Packagetype: "bdist_wheel", RequiresPython: ">=3.7",
+						URL: srv.URL + "/packages/my_package-1.5.0-py3-none-any.whl"},
+				},
+				"2.0.0": {
+					{BaseVersion: "2.0.0", Filename: "my_package-2.0.0-py3-none-any.whl", Packagetype: "bdist_wheel", RequiresPython: ">=3.8",
+						Yanked: true, YankedReason: "security issue", URL: "https://files.example.com/my_package-2.0.0-py3-none-any.whl"},
+				},
+				"3.0.0": nil,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			URL, _ := url.Parse(srv.URL + c.Index)
+			cl := NewSimpleClient(srv.Client(), URL)
+
+			pkg, _, err := cl.Package(context.Background(), "My_Package")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var versions []string
+			for _, v := range pkg.Releases {
+				versions = append(versions, v.Version)
+			}
+			if !reflect.DeepEqual(versions, c.Versions) {
+				t.Errorf("expected %v versions, got %v", c.Versions, versions)
+			}
+			if pkg.Info.Version != c.Latest {
+				t.Errorf("expected %q latest version, got %q", c.Latest, pkg.Info.Version)
+			}
+
+			for version, expected := range c.Files {
+				pkg, _, err := cl.Release(context.Background(), "my-package", version)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(pkg.Releases) != 1 {
+					t.Fatalf("expected single %q version, got %+v", version, pkg.Releases)
+				}
+				files := pkg.Releases[0].Releases
+				for i := range files {
+					files[i].Digests.Sha256, files[i].Digests.Md5 = "", ""
+				}
+				if !reflect.DeepEqual(files, expected) {
+					t.Errorf("unexpected %q files:\n%+v\nexpected:\n%+v", version, files, expected)
+				}
+			}
+
+			if _, _, err = cl.Release(context.Background(), "my-package", "0.0.1"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected ErrNotFound on missing version, got %v", err)
+			}
+			if _, _, err = cl.Package(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected ErrNotFound on missing package, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseFilename(t *testing.T) {
+	cases := []struct {
+		Project, Filename, Version, Type string
+	}{
+		{"requests", "requests-2.25.1-py2.py3-none-any.whl", "2.25.1", "bdist_wheel"},
+		{"requests", "requests-2.25.1.tar.gz", "2.25.1", "sdist"},
+		{"zope.interface", "zope.interface-5.4.0-cp39-cp39-manylinux2010_x86_64.whl", "5.4.0", "bdist_wheel"},
+		{"django-ckeditor", "django-ckeditor-5.3.0.tar.gz", "5.3.0", "sdist"},
+		{"django-ckeditor", "django_ckeditor-6.0.0-1-py2.py3-none-any.whl", "6.0.0", "bdist_wheel"},
+		{"setuptools", "setuptools-0.6c11-py2.7.egg", "0.6c11", "bdist_egg"},
+		{"pillow", "Pillow-8.4.0.zip", "8.4.0", "sdist"},
+		{"pillow", "Pillow-8.4.0.win32-py3.6.exe", "", ""},
+		{"pillow", "README", "", ""},
+	}
+	for _, c := range cases {
+		version, typ := parseFilename(c.Project, c.Filename)
+		if version != c.Version || typ != c.Type {
+			t.Errorf("%q: expected %q (%s), got %q (%s)", c.Filename, c.Version, c.Type, version, typ)
+		}
+	}
+}
+
+func TestComparePipVersions(t *testing.T) {
+	// Every version in the list is less than the next one, not supported versions are the lowest.
+	ordered := []string{"not-a-version", "1.0.dev1", "1.0rc1", "1.0", "1.0.post1", "1.10", "2!0.1"}
+	for i := 0; i < len(ordered)-1; i++ {
+		if cmp := comparePipVersions(ordered[i], ordered[i+1]); cmp != -1 {
+			t.Errorf("expected %q < %q, got compare result %d", ordered[i], ordered[i+1], cmp)
+		}
+		if cmp := comparePipVersions(ordered[i+1], ordered[i]); cmp != 1 {
+			t.Errorf("expected %q > %q, got compare result %d", ordered[i+1], ordered[i], cmp)
+		}
+	}
+	if comparePipVersions("1.0", "1.0.0") != 0 || comparePipVersions("v1.0+local", "1.0") != 0 {
+		t.Errorf("expected equal versions")
+	}
+}
+
+func TestSimpleClient_Credentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = rw.Write([]byte(`<a href="my_package-1.0.0.tar.gz">my_package-1.0.0.tar.gz</a>`))
+	}))
+	defer srv.Close()
+
+	URL, _ := url.Parse(srv.URL + "/simple/")
+	URL.User = url.UserPassword("user", "token")
+	cl := NewSimpleClient(srv.Client(), URL)
+	if cl.(*SimpleClient).indexURL.User != nil {
+		t.Errorf("expected credentials to be removed from the index url")
+	}
+
+	pkg, resp, err := cl.Package(context.Background(), "my-package")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pkg.Releases) != 1 || resp.Request.URL.User != nil {
+		t.Errorf("unexpected package: %+v", pkg)
+	}
+
+	URL.User = nil
+	if _, _, err = NewSimpleClient(srv.Client(), URL).Package(context.Background(), "my-package"); err == nil {
+		t.Errorf("expected error without credentials")
+	}
+}
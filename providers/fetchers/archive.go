@@ -0,0 +1,312 @@
+package fetchers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxArchiveSize is the default ArchiveFetcher total uncompressed size limit (512 MiB).
+const DefaultMaxArchiveSize = 512 << 20
+
+var (
+	// ErrUnsupportedArchive is returned when the archive format is not zip, tar or gzipped tar.
+	ErrUnsupportedArchive = errors.New("unsupported archive format")
+	// ErrUnsafeArchivePath is returned when the archive entry path leaves the archive root (zip-slip).
+	ErrUnsafeArchivePath = errors.New("archive entry path is outside of the archive root")
+	// ErrArchiveTooLarge is returned when the archive exceeds the fetcher total size limit (e.g. decompression bomb).
+	ErrArchiveTooLarge = errors.New("archive is too large")
+)
+
+// ArchiveOptions represents ArchiveFetcher options.
+type ArchiveOptions struct {
+	// StripTopDir strips the top-level directory from the entries paths (e.g. 'project-1.0.0/composer.json' becomes 'composer.json').
+	StripTopDir bool
+	// MaxFileSize is the entry uncompressed size limit in bytes, default is DefaultMaxFileSize.
+	MaxFileSize int64
+	// MaxTotalSize is the archive uncompressed (and downloaded) size limit in bytes, default is DefaultMaxArchiveSize.
+	MaxTotalSize int64
+}
+
+// ArchiveFetcher reads files directly from the archive (zip, tar or gzipped tar) without unpacking it to disk.
+type ArchiveFetcher struct {
+	opts ArchiveOptions
+	// files - archive entries by the root-related path, every entry is either zip file or tar file content
+	files map[string]archiveEntry
+}
+
+// archiveEntry represents one archive file.
+type archiveEntry struct {
+	zipFile *zip.File
+	content []byte
+	// err is the entry reading error (e.g. the entry is too large)
+	err error
+}
+
+// NewArchiveFetcher constructs ArchiveFetcher reading the archive of the specified size, format is detected by the content.
+//
+// Zip entries are decompressed on demand, tar entries are read into memory within the size limits.
+func NewArchiveFetcher(r io.ReaderAt, size int64, opts *ArchiveOptions) (*ArchiveFetcher, error) {
+	af := &ArchiveFetcher{files: map[string]archiveEntry{}}
+	if opts != nil {
+		af.opts = *opts
+	}
+	if af.opts.MaxFileSize <= 0 {
+		af.opts.MaxFileSize = DefaultMaxFileSize
+	}
+	if af.opts.MaxTotalSize <= 0 {
+		af.opts.MaxTotalSize = DefaultMaxArchiveSize
+	}
+
+	magic := make([]byte, 512)
+	n, err := r.ReadAt(magic, 0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read the archive: %w", err)
+	}
+	magic = magic[:n]
+
+	switch {
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")) || bytes.HasPrefix(magic, []byte("PK\x05\x06")):
+		err = af.readZip(r, size)
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		var gz *gzip.Reader
+		gz, err = gzip.NewReader(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read gzip archive: %w", err)
+		}
+		err = af.readTar(gz)
+	case len(magic) > 262 && string(magic[257:262]) == "ustar":
+		err = af.readTar(io.NewSectionReader(r, 0, size))
+	default:
+		return nil, ErrUnsupportedArchive
+	}
+	if err != nil {
+		return nil, err
+	}
+	return af, nil
+}
+
+// NewArchiveFileFetcher constructs ArchiveFetcher reading the local archive file.
+//
+// Archive file is read into memory (within MaxTotalSize limit), so it can be removed after the call.
+func NewArchiveFileFetcher(name string, opts *ArchiveOptions) (*ArchiveFetcher, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the archive: %w", err)
+	}
+	defer f.Close()
+	return readArchive(f, opts)
+}
+
+// NewArchiveURLFetcher constructs ArchiveFetcher downloading the archive from the URL (within MaxTotalSize limit).
+//
+// If httpClient is nil - default value will be used.
+func NewArchiveURLFetcher(ctx context.Context, httpClient *http.Client, URL string, opts *ArchiveOptions) (*ArchiveFetcher, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download the archive: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("archive server responded with HTTP error '%d: %s'", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return readArchive(resp.Body, opts)
+}
+
+// readArchive - helper to read the whole archive into memory and construct ArchiveFetcher.
+func readArchive(r io.Reader, opts *ArchiveOptions) (*ArchiveFetcher, error) {
+	limit := int64(DefaultMaxArchiveSize)
+	if opts != nil && opts.MaxTotalSize > 0 {
+		limit = opts.MaxTotalSize
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the archive: %w", err)
+	}
+	if int64(len(b)) > limit {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrArchiveTooLarge, limit)
+	}
+	return NewArchiveFetcher(bytes.NewReader(b), int64(len(b)), opts)
+}
+
+// FileContent reads specified file content from the archive.
+// Path argument is the root-related file path.
+func (af ArchiveFetcher) FileContent(ctx context.Context, name string) ([]byte, error) {
+	entry, ok := af.files[path.Clean(strings.TrimPrefix(name, "/"))]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	if entry.zipFile == nil {
+		return entry.content, nil
+	}
+
+	rc, err := entry.zipFile.Open()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load '%s' file from the archive: %w", name, err)
+	}
+	defer rc.Close()
+	return af.readEntry(name, rc)
+}
+
+// ListFiles returns sorted root-related paths of the archive files matching the pattern.
+func (af ArchiveFetcher) ListFiles(ctx context.Context, pattern string) ([]string, error) {
+	res := []string{}
+	for name := range af.files {
+		if MatchPattern(pattern, name) {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// readZip indexes zip archive entries.
+func (af *ArchiveFetcher) readZip(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("unable to read zip archive: %w", err)
+	}
+
+	var total uint64
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		name, ok, err := af.entryName(f.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		// Declared sizes are checked upfront, actual sizes are limited on read
+		total += f.UncompressedSize64
+		if total > uint64(af.opts.MaxTotalSize) {
+			return fmt.Errorf("%w: limit is %d bytes", ErrArchiveTooLarge, af.opts.MaxTotalSize)
+		}
+		af.files[name] = archiveEntry{zipFile: f}
+	}
+	return nil
+}
+
+// readTar reads tar archive regular files into memory.
+//
+// Declared sizes of all the entries (including skipped ones) and the whole (decompressed) tar stream
+// are limited by MaxTotalSize, so skipped entries of decompression bombs are not read in full.
+func (af *ArchiveFetcher) readTar(r io.Reader) error {
+	tr := tar.NewReader(&archiveLimitReader{r: r, limit: af.opts.MaxTotalSize})
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if errors.Is(err, ErrArchiveTooLarge) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read tar archive: %w", err)
+		}
+		total += hdr.Size
+		if hdr.Size < 0 || total > af.opts.MaxTotalSize {
+			return fmt.Errorf("%w: limit is %d bytes", ErrArchiveTooLarge, af.opts.MaxTotalSize)
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		name, ok, err := af.entryName(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		content, err := af.readEntry(name, tr)
+		if errors.Is(err, ErrFileTooLarge) {
+			// Large files are not kept in memory, the error is reported on FileContent call
+			af.files[name] = archiveEntry{err: err}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		af.files[name] = archiveEntry{content: content}
+	}
+}
+
+// archiveLimitReader fails with ErrArchiveTooLarge once more than limit bytes are read.
+type archiveLimitReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+// Read implements io.Reader interface.
+func (lr *archiveLimitReader) Read(p []byte) (int, error) {
+	if lr.read >= lr.limit {
+		// Probe the stream, the archive exactly of the limit size is fine
+		var probe [1]byte
+		if n, err := lr.r.Read(probe[:]); n == 0 {
+			return 0, err
+		}
+		return 0, fmt.Errorf("%w: limit is %d bytes", ErrArchiveTooLarge, lr.limit)
+	}
+	if int64(len(p)) > lr.limit-lr.read {
+		p = p[:lr.limit-lr.read]
+	}
+	n, err := lr.r.Read(p)
+	lr.read += int64(n)
+	return n, err
+}
+
+// readEntry reads the archive entry content within the file size limit.
+func (af ArchiveFetcher) readEntry(name string, r io.Reader) ([]byte, error) {
+	b, err := ioutil.ReadAll(io.LimitReader(r, af.opts.MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load '%s' file from the archive: %w", name, err)
+	}
+	if int64(len(b)) > af.opts.MaxFileSize {
+		return nil, &FileTooLargeError{Path: name, Limit: af.opts.MaxFileSize}
+	}
+	return b, nil
+}
+
+// entryName returns root-related entry path, false is returned if the entry should be skipped (e.g. stripped top-level file).
+func (af ArchiveFetcher) entryName(raw string) (string, bool, error) {
+	name := strings.ReplaceAll(raw, "\\", "/")
+	clean := path.Clean(name)
+	if path.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", false, fmt.Errorf("%w: %q", ErrUnsafeArchivePath, raw)
+	}
+	clean = strings.TrimPrefix(clean, "./")
+	if af.opts.StripTopDir {
+		i := strings.Index(clean, "/")
+		if i < 0 {
+			return "", false, nil
+		}
+		clean = clean[i+1:]
+	}
+	return clean, clean != "" && clean != ".", nil
+}
@@ -0,0 +1,215 @@
+package fetchers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// zipArchive creates zip archive with the files ('path:content' map).
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("unable to create zip entry: %v", err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("unable to create zip archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// tarGzArchive creates gzipped tar archive with the files ('path:content' map).
+func tarGzArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("unable to create tar entry: %v", err)
+		}
+		_, _ = tw.Write([]byte(content))
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("unable to create tar archive: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("unable to create gzip archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestArchiveFetcher_FileContent(t *testing.T) {
+	files := map[string]string{
+		"project-1.0.0/composer.json":              `{"require": {}}`,
+		"project-1.0.0/services/api/composer.json": `{}`,
+		"project-1.0.0/large.bin":                  "0123456789",
+	}
+	archives := map[string][]byte{
+		"zip":    zipArchive(t, files),
+		"tar.gz": tarGzArchive(t, files),
+	}
+
+	for format, archive := range archives {
+		t.Run(format, func(t *testing.T) {
+			fetcher, err := NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), &ArchiveOptions{StripTopDir: true, MaxFileSize: 5})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			content, err := fetcher.FileContent(context.Background(), "composer.json")
+			if err == nil || !errors.Is(err, ErrFileTooLarge) {
+				t.Errorf("expected file too large error, got: %q, %v", content, err)
+			}
+
+			fetcher, err = NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), &ArchiveOptions{StripTopDir: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			content, err = fetcher.FileContent(context.Background(), "composer.json")
+			if err != nil || string(content) != `{"require": {}}` {
+				t.Errorf("unexpected file content: %q, %v", content, err)
+			}
+			if content, err = fetcher.FileContent(context.Background(), "project-1.0.0/composer.json"); err != ErrFileNotFound {
+				t.Errorf("expected file not found error on stripped path, got: %q, %v", content, err)
+			}
+			list, err := fetcher.ListFiles(context.Background(), "**/composer.json")
+			if err != nil || !reflect.DeepEqual(list, []string{"composer.json", "services/api/composer.json"}) {
+				t.Errorf("unexpected listed files: %+v, %v", list, err)
+			}
+
+			fetcher, err = NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err = fetcher.FileContent(context.Background(), "project-1.0.0/composer.json"); err != nil {
+				t.Errorf("unexpected error on not stripped path: %v", err)
+			}
+
+			_, err = NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), &ArchiveOptions{MaxTotalSize: 20})
+			if !errors.Is(err, ErrArchiveTooLarge) {
+				t.Errorf("expected archive too large error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestArchiveFetcher_Errors(t *testing.T) {
+	for format, archive := range map[string][]byte{
+		"zip":    zipArchive(t, map[string]string{"../../composer.json": "{}"}),
+		"tar.gz": tarGzArchive(t, map[string]string{"/etc/composer.json": "{}"}),
+	} {
+		_, err := NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), nil)
+		if !errors.Is(err, ErrUnsafeArchivePath) {
+			t.Errorf("expected unsafe path error on %s archive, got: %v", format, err)
+		}
+	}
+
+	plain := []byte("not an archive")
+	if _, err := NewArchiveFetcher(bytes.NewReader(plain), int64(len(plain)), nil); err != ErrUnsupportedArchive {
+		t.Errorf("expected unsupported archive error, got: %v", err)
+	}
+}
+
+func TestArchiveFetcher_TarBomb(t *testing.T) {
+	// Highly compressible entry skipped by the top-level directory stripping
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	size := int64(32 << 20)
+	if err := tw.WriteHeader(&tar.Header{Name: "bomb.bin", Mode: 0o644, Size: size, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("unable to create tar entry: %v", err)
+	}
+	zeros := make([]byte, 1<<20)
+	for written := int64(0); written < size; written += int64(len(zeros)) {
+		_, _ = tw.Write(zeros)
+	}
+	_ = tw.Close()
+	_ = gw.Close()
+	if buf.Len() > 1<<20 {
+		t.Fatalf("expected small compressed archive, got %d bytes", buf.Len())
+	}
+
+	archive := buf.Bytes()
+	opts := &ArchiveOptions{StripTopDir: true, MaxFileSize: 1024, MaxTotalSize: 1 << 20}
+	if _, err := NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), opts); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("expected archive too large error on skipped entry, got: %v", err)
+	}
+	opts.StripTopDir = false
+	if _, err := NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), opts); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("expected archive too large error on large entry, got: %v", err)
+	}
+
+	// Declared sizes fit the limit, but the decompressed stream doesn't
+	files := map[string]string{}
+	for i := 0; i < 100; i++ {
+		files[fmt.Sprintf("pkg/empty-%d.txt", i)] = ""
+	}
+	archive = tarGzArchive(t, files)
+	if _, err := NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), &ArchiveOptions{MaxTotalSize: 8192}); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("expected archive too large error on decompressed stream, got: %v", err)
+	}
+	if _, err := NewArchiveFetcher(bytes.NewReader(archive), int64(len(archive)), nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestArchiveFetcher_Sources(t *testing.T) {
+	archive := tarGzArchive(t, map[string]string{"pkg/requirements.txt": "Django==3.2.9"})
+
+	name := filepath.Join(localTree(t, nil), "pkg.tar.gz")
+	if err := ioutil.WriteFile(name, archive, 0o644); err != nil {
+		t.Fatalf("unable to write archive: %v", err)
+	}
+	fetcher, err := NewArchiveFileFetcher(name, &ArchiveOptions{StripTopDir: true})
+	if err != nil {
+		t.Fatalf("unexpected error on archive file: %v", err)
+	}
+	if content, err := fetcher.FileContent(context.Background(), "requirements.txt"); err != nil || string(content) != "Django==3.2.9" {
+		t.Errorf("unexpected file content: %q, %v", content, err)
+	}
+	if _, err := NewArchiveFileFetcher(name+".missing", nil); err == nil {
+		t.Errorf("expected error on missing archive file, got none")
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pkg.tar.gz" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(archive)
+	}))
+	defer srv.Close()
+
+	fetcher, err = NewArchiveURLFetcher(context.Background(), srv.Client(), srv.URL+"/pkg.tar.gz", nil)
+	if err != nil {
+		t.Fatalf("unexpected error on archive url: %v", err)
+	}
+	if _, err := fetcher.FileContent(context.Background(), "pkg/requirements.txt"); err != nil {
+		t.Errorf("unexpected error on downloaded archive: %v", err)
+	}
+	_, err = NewArchiveURLFetcher(context.Background(), srv.Client(), srv.URL+"/missing.zip", nil)
+	if err == nil || err.Error() != "archive server responded with HTTP error '404: Not Found'" {
+		t.Errorf("expected HTTP error on missing archive, got: %v", err)
+	}
+	_, err = NewArchiveURLFetcher(context.Background(), srv.Client(), srv.URL+"/pkg.tar.gz", &ArchiveOptions{MaxTotalSize: 10})
+	if !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("expected archive too large error on download, got: %v", err)
+	}
+}
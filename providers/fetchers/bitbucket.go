@@ -0,0 +1,95 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// bitbucketAPIURL - Bitbucket Cloud API base url (used as default BitbucketFetcher baseURL)
+var bitbucketAPIURL *url.URL
+
+// bitbucketAPIHostname - Bitbucket Cloud API 2.0 address.
+var bitbucketAPIHostname string = "https://api.bitbucket.org/2.0/"
+
+func init() {
+	bitbucketAPIURL, _ = url.Parse(bitbucketAPIHostname)
+}
+
+// BitbucketFetcher fetches files from the specified Bitbucket Cloud or Bitbucket Server (Data Center) repository.
+// Owner is the workspace (Cloud) or the project key (Server), Repo is the repository slug.
+type BitbucketFetcher struct {
+	Owner string
+	Repo  string
+	SHA   string
+	// Token is the access token (or app password in 'username:password' form), sent with 'Authorization' header (if not empty).
+	Token string
+	// Server indicates Bitbucket Server (Data Center) REST API usage instead of Bitbucket Cloud one.
+	Server     bool
+	baseURL    url.URL
+	httpClient *http.Client
+}
+
+// NewBitbucketFetcher constructs Bitbucket Cloud BitbucketFetcher with specified parameters.
+//
+// If httpClient or baseURL is nil - default values will be used ('https://api.bitbucket.org/2.0/').
+func NewBitbucketFetcher(httpClient *http.Client, baseURL *url.URL, owner, repo, sha, token string) FileFetcher {
+	if baseURL == nil {
+		baseURL = bitbucketAPIURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &BitbucketFetcher{Owner: owner, Repo: repo, SHA: sha, Token: token, baseURL: *baseURL, httpClient: httpClient}
+}
+
+// NewBitbucketServerFetcher constructs Bitbucket Server (Data Center) BitbucketFetcher with specified parameters.
+//
+// baseURL is the instance address (e.g. 'https://bitbucket.example.com/'), if httpClient is nil - default value will be used.
+func NewBitbucketServerFetcher(httpClient *http.Client, baseURL url.URL, project, repo, sha, token string) FileFetcher {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &BitbucketFetcher{Owner: project, Repo: repo, SHA: sha, Token: token, Server: true, baseURL: baseURL, httpClient: httpClient}
+}
+
+// FileContent fetches specified file content from the configured repository.
+// Path argument is the root-related file path.
+func (p BitbucketFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
+	var route string
+	base := strings.TrimSuffix(p.baseURL.String(), "/")
+	if p.Server {
+		route = fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/raw/%s", base, url.PathEscape(p.Owner), url.PathEscape(p.Repo), escapePath(path))
+		if p.SHA != "" {
+			route += "?at=" + url.QueryEscape(p.SHA)
+		}
+	} else {
+		ref := p.SHA
+		if ref == "" {
+			ref = "HEAD"
+		}
+		route = fmt.Sprintf("%s/repositories/%s/%s/src/%s/%s", base, url.PathEscape(p.Owner), url.PathEscape(p.Repo), url.PathEscape(ref), escapePath(path))
+	}
+
+	header := http.Header{}
+	if p.Token != "" {
+		if strings.Contains(p.Token, ":") {
+			header.Set("Authorization", "Basic "+basicAuth(p.Token))
+		} else {
+			header.Set("Authorization", "Bearer "+p.Token)
+		}
+	}
+	return httpFile(ctx, p.httpClient, "bitbucket", route, path, header)
+}
+
+// CacheKey returns the repository file cache key (e.g. 'api.bitbucket.org/owner/repo@ref:path').
+func (p BitbucketFetcher) CacheKey(path string) string {
+	return fmt.Sprintf("%s/%s@%s:%s", p.baseURL.Host, p.Owner+"/"+p.Repo, p.SHA, path)
+}
+
+// Revision returns the fetched revision (commit hash/branch/tag).
+func (p BitbucketFetcher) Revision() string {
+	return p.SHA
+}
@@ -0,0 +1,65 @@
+package fetchers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestBitbucketFetcher_FileContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/2.0/repositories/workspace/repo/src/main/services/composer.json":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"cloud": true}`))
+		case "/2.0/repositories/workspace/repo/src/HEAD/composer.json":
+			if r.Header.Get("Authorization") != "Basic dXNlcjpwYXNz" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"head": true}`))
+		case "/rest/api/1.0/projects/PRJ/repos/repo/raw/services/composer.json?at=refs%2Fheads%2Fmain":
+			_, _ = rw.Write([]byte(`{"server": true}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	cloudURL, _ := url.Parse(srv.URL + "/2.0/")
+	serverURL, _ := url.Parse(srv.URL)
+
+	cases := []struct {
+		Name     string
+		Fetcher  FileFetcher
+		Path     string
+		Expected string
+		Error    error
+	}{
+		{"cloud", NewBitbucketFetcher(srv.Client(), cloudURL, "workspace", "repo", "main", "secret"), "services/composer.json", `{"cloud": true}`, nil},
+		{"cloud app password", NewBitbucketFetcher(srv.Client(), cloudURL, "workspace", "repo", "", "user:pass"), "composer.json", `{"head": true}`, nil},
+		{"cloud not found", NewBitbucketFetcher(srv.Client(), cloudURL, "workspace", "repo", "main", "secret"), "missing.json", "", ErrFileNotFound},
+		{"server", NewBitbucketServerFetcher(srv.Client(), *serverURL, "PRJ", "repo", "refs/heads/main", ""), "services/composer.json", `{"server": true}`, nil},
+		{"server not found", NewBitbucketServerFetcher(srv.Client(), *serverURL, "PRJ", "repo", "", ""), "services/composer.json", "", ErrFileNotFound},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Name, func(t *testing.T) {
+			content, err := cs.Fetcher.FileContent(context.Background(), cs.Path)
+			if err != cs.Error {
+				t.Fatalf("unexpected error, expected %v, got: %v", cs.Error, err)
+			}
+			if string(content) != cs.Expected {
+				t.Errorf("unexpected file content: %q", content)
+			}
+		})
+	}
+
+	_, err := NewBitbucketFetcher(srv.Client(), cloudURL, "workspace", "repo", "main", "").FileContent(context.Background(), "services/composer.json")
+	if err == nil || err.Error() != "bitbucket responded with HTTP error '401: Unauthorized'" {
+		t.Errorf("expected HTTP error without token, got: %v", err)
+	}
+}
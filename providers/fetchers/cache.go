@@ -0,0 +1,239 @@
+package fetchers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CacheableFetcher interface defines fetchers capable of identifying their files for caching.
+type CacheableFetcher interface {
+	FileFetcher
+	// CacheKey returns the file cache key, unique across repositories and revisions (e.g. 'api.github.com/owner/repo@ref:path').
+	CacheKey(path string) string
+	// Revision returns the fetched revision (commit hash/branch/tag).
+	Revision() string
+}
+
+// ConditionalFetcher interface defines fetchers supporting conditional (ETag based) requests.
+type ConditionalFetcher interface {
+	// FileContentETag fetches file content unless it's ETag matches the passed one, notModified is true if it does.
+	FileContentETag(ctx context.Context, path, etag string) (content []byte, newETag string, notModified bool, err error)
+}
+
+// CacheEntry represents cached file.
+type CacheEntry struct {
+	Content []byte `json:"content"`
+	// ETag is used to revalidate the entry (if the fetcher supports conditional requests).
+	ETag string `json:"etag,omitempty"`
+	// NotFound indicates that the file doesn't exist.
+	NotFound bool `json:"not_found,omitempty"`
+	// Immutable entries are never revalidated (revision is a full commit hash).
+	Immutable bool `json:"immutable,omitempty"`
+}
+
+// Cache interface defines CachingFetcher storage backends.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+}
+
+// NewMemoryCache constructs in-memory Cache implementation.
+func NewMemoryCache() Cache {
+	return &MemoryCache{entries: map[string]CacheEntry{}}
+}
+
+// MemoryCache represents in-memory Cache implementation, it's safe for concurrent use.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]CacheEntry
+}
+
+// Get returns cached entry (if any).
+func (mc *MemoryCache) Get(key string) (CacheEntry, bool) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	entry, ok := mc.entries[key]
+	return entry, ok
+}
+
+// Set stores the entry.
+func (mc *MemoryCache) Set(key string, entry CacheEntry) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.entries[key] = entry
+}
+
+// NewDiskCache constructs on-disk Cache implementation storing entries in the directory (it's created if missing).
+func NewDiskCache(dir string) Cache {
+	return &DiskCache{Dir: dir}
+}
+
+// DiskCache represents on-disk Cache implementation, every entry is stored as a JSON file named by the key hash.
+//
+// Storage errors are not reported: unreadable entries are treated as missing and failed writes are skipped.
+type DiskCache struct {
+	Dir string
+}
+
+// Get returns cached entry (if any).
+func (dc DiskCache) Get(key string) (CacheEntry, bool) {
+	var entry CacheEntry
+	b, err := ioutil.ReadFile(dc.filename(key))
+	if err != nil {
+		return entry, false
+	}
+	if err = json.Unmarshal(b, &entry); err != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+// Set stores the entry, file is replaced atomically.
+func (dc DiskCache) Set(key string, entry CacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(dc.Dir, 0o755); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(dc.Dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dc.filename(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// filename returns the entry file path.
+func (dc DiskCache) filename(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dc.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// CachingFetcher is FileFetcher decorator caching files contents.
+//
+// Files of full commit hash revisions are cached forever, other files are revalidated once per CachingFetcher
+// lifetime with conditional requests (if the fetcher supports them) or fetched again.
+type CachingFetcher struct {
+	fetcher FileFetcher
+	cache   Cache
+
+	mu        sync.Mutex
+	validated map[string]bool
+}
+
+// NewCachingFetcher constructs CachingFetcher decorating the fetcher, if cache is nil - in-memory cache is used.
+// Returned fetcher implements FileLister if the decorated one does (listings are not cached).
+//
+// Keys of the fetchers not implementing CacheableFetcher are the plain file paths, so the cache
+// shouldn't be shared between such fetchers.
+func NewCachingFetcher(fetcher FileFetcher, cache Cache) FileFetcher {
+	if cache == nil {
+		cache = NewMemoryCache()
+	}
+	cf := &CachingFetcher{fetcher: fetcher, cache: cache, validated: map[string]bool{}}
+	if lister, ok := fetcher.(FileLister); ok {
+		return &cachingListerFetcher{CachingFetcher: cf, lister: lister}
+	}
+	return cf
+}
+
+// cachingListerFetcher is CachingFetcher of the fetcher capable of listing files.
+type cachingListerFetcher struct {
+	*CachingFetcher
+	lister FileLister
+}
+
+// ListFiles lists decorated fetcher files.
+func (cf *cachingListerFetcher) ListFiles(ctx context.Context, pattern string) ([]string, error) {
+	return cf.lister.ListFiles(ctx, pattern)
+}
+
+// FileContent returns cached (or fetched) file content.
+func (cf *CachingFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
+	key, immutable := path, false
+	if cacheable, ok := cf.fetcher.(CacheableFetcher); ok {
+		key, immutable = cacheable.CacheKey(path), IsCommitHash(cacheable.Revision())
+	}
+
+	entry, ok := cf.cache.Get(key)
+	if ok && (entry.Immutable || cf.isValidated(key)) {
+		return entry.content()
+	}
+
+	conditional, isConditional := cf.fetcher.(ConditionalFetcher)
+	var err error
+	var content []byte
+	var etag string
+	if isConditional {
+		var notModified bool
+		if !ok || entry.NotFound {
+			entry.ETag = ""
+		}
+		content, etag, notModified, err = conditional.FileContentETag(ctx, path, entry.ETag)
+		if err == nil && notModified {
+			cf.setValidated(key)
+			return entry.content()
+		}
+	} else {
+		content, err = cf.fetcher.FileContent(ctx, path)
+	}
+
+	switch {
+	case err == ErrFileNotFound:
+		entry = CacheEntry{NotFound: true, Immutable: immutable}
+	case err != nil:
+		return nil, err
+	default:
+		entry = CacheEntry{Content: content, ETag: etag, Immutable: immutable}
+	}
+	cf.cache.Set(key, entry)
+	cf.setValidated(key)
+	return entry.content()
+}
+
+// isValidated reports whether the entry was fetched or revalidated by the fetcher.
+func (cf *CachingFetcher) isValidated(key string) bool {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	return cf.validated[key]
+}
+
+// setValidated marks the entry as fetched or revalidated by the fetcher.
+func (cf *CachingFetcher) setValidated(key string) {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	cf.validated[key] = true
+}
+
+// content returns entry content or ErrFileNotFound.
+func (ce CacheEntry) content() ([]byte, error) {
+	if ce.NotFound {
+		return nil, ErrFileNotFound
+	}
+	return ce.Content, nil
+}
+
+// IsCommitHash reports whether the revision is a full commit hash (SHA-1 or SHA-256), such revisions are immutable.
+func IsCommitHash(rev string) bool {
+	if len(rev) != 40 && len(rev) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(rev)
+	return err == nil
+}
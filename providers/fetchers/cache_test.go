@@ -0,0 +1,120 @@
+package fetchers
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCachingFetcher_GitHub(t *testing.T) {
+	var requests, revalidations int32
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/repos/test/testing/contents/missing.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&revalidations, 1)
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write([]byte(`{"content" : "Hello world!"}`))
+	}))
+	cache := NewDiskCache(localTree(t, nil))
+
+	cases := []struct {
+		Name          string
+		SHA           string
+		Requests      int32
+		Revalidations int32
+	}{
+		// Second fetcher (next run) revalidates the branch file once
+		{"branch", "main", 2, 1},
+		// Full commit hash files are never revalidated
+		{"commit", "0123456789abcdef0123456789abcdef01234567", 1, 0},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Name, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+			atomic.StoreInt32(&revalidations, 0)
+			for run := 0; run < 2; run++ {
+				fetcher := NewCachingFetcher(NewGitHubFetcher(cl, "test", "testing", cs.SHA), cache)
+				for i := 0; i < 2; i++ {
+					content, err := fetcher.FileContent(context.Background(), "composer.json")
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if string(content) != "Hello world!" {
+						t.Errorf("unexpected file content: %q", content)
+					}
+				}
+			}
+			if atomic.LoadInt32(&requests) != cs.Requests || atomic.LoadInt32(&revalidations) != cs.Revalidations {
+				t.Errorf("expected %d requests and %d revalidations, got %d and %d", cs.Requests, cs.Revalidations, requests, revalidations)
+			}
+		})
+	}
+
+	atomic.StoreInt32(&requests, 0)
+	fetcher := NewCachingFetcher(NewGitHubFetcher(cl, "test", "testing", "main"), nil)
+	for i := 0; i < 2; i++ {
+		if content, err := fetcher.FileContent(context.Background(), "missing.json"); err != ErrFileNotFound || content != nil {
+			t.Errorf("expected file not found error, got: %q, %v", content, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected missing file to be cached, got %d requests", requests)
+	}
+}
+
+func TestCachingFetcher_Lister(t *testing.T) {
+	files := map[string][]byte{"composer.json": []byte("{}")}
+	fetcher := NewCachingFetcher(ByteMapFetcher{Files: files}, NewMemoryCache())
+	lister, ok := fetcher.(FileLister)
+	if !ok {
+		t.Fatalf("expected caching fetcher to keep files listing support")
+	}
+	list, err := lister.ListFiles(context.Background(), "*.json")
+	if err != nil || !reflect.DeepEqual(list, []string{"composer.json"}) {
+		t.Errorf("unexpected listed files: %+v, %v", list, err)
+	}
+
+	// Plain fetchers are cached by path for the fetcher lifetime
+	content, _ := fetcher.FileContent(context.Background(), "composer.json")
+	files["composer.json"] = []byte(`{"require": {}}`)
+	cached, _ := fetcher.FileContent(context.Background(), "composer.json")
+	if string(content) != "{}" || string(cached) != "{}" {
+		t.Errorf("unexpected cached file content: %q, %q", content, cached)
+	}
+
+	if _, ok := NewCachingFetcher(plainFetcher{}, nil).(FileLister); ok {
+		t.Errorf("expected caching fetcher without files listing support")
+	}
+}
+
+func TestIsCommitHash(t *testing.T) {
+	cases := map[string]bool{
+		"0123456789abcdef0123456789abcdef01234567":                         true,
+		"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef": true,
+		"main":    false,
+		"v1.0.0":  false,
+		"0123abc": false,
+		"0123456789abcdef0123456789abcdef0123456z": false,
+	}
+	for rev, expected := range cases {
+		if IsCommitHash(rev) != expected {
+			t.Errorf("unexpected commit hash check result for %q, expected %t", rev, expected)
+		}
+	}
+}
+
+// plainFetcher is a fetcher without files listing support.
+type plainFetcher struct{}
+
+func (plainFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
+	return nil, ErrFileNotFound
+}
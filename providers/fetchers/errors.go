@@ -0,0 +1,134 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dephub/dephub-core/providers/transport"
+)
+
+// Fetching errors kinds, use errors.Is to check the FetchError kind.
+var (
+	// ErrUnauthorized is returned when the credentials are missing or invalid (re-authentication is required).
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the credentials don't grant access to the repository.
+	ErrForbidden = errors.New("access forbidden")
+	// ErrRateLimited is returned when the hosting rate limit is exceeded, the request can be retried after the reset time.
+	ErrRateLimited = transport.ErrRateLimited
+	// ErrRefNotFound is returned when the repository or it's revision (branch/tag/commit) doesn't exist.
+	ErrRefNotFound = errors.New("revision not found")
+	// ErrNetwork is returned when the hosting is unreachable (the request can be retried).
+	ErrNetwork = errors.New("network error")
+)
+
+// FetchError represents the hosting API failure.
+type FetchError struct {
+	// Provider is the hosting name (e.g. 'github').
+	Provider string
+	// Path is the requested file path, empty for non-file requests (e.g. listing).
+	Path string
+	// StatusCode is the HTTP response status code, zero on network errors.
+	StatusCode int
+	// Reset is the rate limit reset time (if known).
+	Reset time.Time
+	// Kind is one of the fetching errors kinds (e.g. ErrUnauthorized), nil for other failures.
+	Kind error
+	// Err is the underlying error (if any).
+	Err error
+}
+
+// Error returns the error message.
+func (e *FetchError) Error() string {
+	if e.StatusCode == 0 && e.Path == "" {
+		return fmt.Sprintf("%s request failed: %v", e.Provider, e.Err)
+	}
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("unable to load '%s' file from %s: %v", e.Path, e.Provider, e.Err)
+	}
+	msg := fmt.Sprintf("%s responded with HTTP error '%d: %s'", e.Provider, e.StatusCode, http.StatusText(e.StatusCode))
+	if !e.Reset.IsZero() {
+		msg += fmt.Sprintf(" (rate limit resets at %s)", e.Reset.Format(time.RFC3339))
+	}
+	return msg
+}
+
+// Is reports whether the target is the error kind.
+func (e *FetchError) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
+
+// Unwrap returns the underlying error.
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitReset returns the rate limit reset time if the error is caused by the exceeded rate limit
+// and the reset time is known.
+func RateLimitReset(err error) (time.Time, bool) {
+	var fe *FetchError
+	if errors.As(err, &fe) && fe.Kind == ErrRateLimited && !fe.Reset.IsZero() {
+		return fe.Reset, true
+	}
+	var rle *transport.RateLimitError
+	if errors.As(err, &rle) && !rle.Reset.IsZero() {
+		return rle.Reset, true
+	}
+	return time.Time{}, false
+}
+
+// statusError - helper to construct FetchError by the HTTP response status code.
+func statusError(provider, path string, resp *http.Response, err error) *FetchError {
+	fe := &FetchError{Provider: provider, Path: path, StatusCode: resp.StatusCode, Err: err}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		fe.Kind = ErrUnauthorized
+	case http.StatusForbidden:
+		fe.Kind = ErrForbidden
+	}
+	if transport.CheckRateLimit(resp) != nil {
+		fe.Kind, fe.Reset = ErrRateLimited, transport.ResetTime(resp.Header)
+	}
+	return fe
+}
+
+// networkError - helper to construct FetchError of the failed request, context errors are returned as is.
+//
+// Requests rejected by the rate limit aware transport (see transport.Transport) are rate limit errors.
+func networkError(ctx context.Context, provider, path string, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var rle *transport.RateLimitError
+	if errors.As(err, &rle) {
+		return &FetchError{Provider: provider, Path: path, Reset: rle.Reset, Kind: ErrRateLimited, Err: err}
+	}
+	return &FetchError{Provider: provider, Path: path, Kind: ErrNetwork, Err: err}
+}
+
+// ErrFileTooLarge is returned when the requested file exceeds the fetcher size limit.
+var ErrFileTooLarge = errors.New("file is too large")
+
+// FileTooLargeError is returned when the requested file exceeds the fetcher size limit, it matches ErrFileTooLarge.
+type FileTooLargeError struct {
+	Path string
+	// Size is the file size in bytes, zero if it's unknown (e.g. the file is read up to the limit).
+	Size int64
+	// Limit is the fetcher size limit in bytes.
+	Limit int64
+}
+
+// Error returns the error message.
+func (e *FileTooLargeError) Error() string {
+	if e.Size > 0 {
+		return fmt.Sprintf("%s: '%s' is %d bytes (limit is %d)", ErrFileTooLarge, e.Path, e.Size, e.Limit)
+	}
+	return fmt.Sprintf("%s: '%s' exceeds %d bytes limit", ErrFileTooLarge, e.Path, e.Limit)
+}
+
+// Is reports whether the target is ErrFileTooLarge.
+func (e *FileTooLargeError) Is(target error) bool {
+	return target == ErrFileTooLarge
+}
@@ -0,0 +1,133 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dephub/dephub-core/providers/transport"
+)
+
+func TestGitHubFetcher_Errors(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/test/testing/contents/unauthorized.json":
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = rw.Write([]byte(`{"message": "Bad credentials"}`))
+		case "/repos/test/testing/contents/forbidden.json":
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+		case "/repos/test/testing/contents/limited.json":
+			rw.Header().Set("X-RateLimit-Limit", "60")
+			rw.Header().Set("X-RateLimit-Remaining", "0")
+			rw.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"message": "API rate limit exceeded"}`))
+		case "/repos/test/testing/contents/ref.json":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message": "No commit found for the ref missing"}`))
+		case "/repos/test/testing/git/trees/HEAD":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"message": "Not Found"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	cases := map[string]error{
+		"unauthorized.json": ErrUnauthorized,
+		"forbidden.json":    ErrForbidden,
+		"limited.json":      ErrRateLimited,
+		"ref.json":          ErrRefNotFound,
+		"missing.json":      ErrFileNotFound,
+	}
+	for name, expected := range cases {
+		fetcher := NewGitHubFetcher(cl, "test", "testing", "")
+		_, err := fetcher.FileContent(context.Background(), name)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected %q error on %s, got: %v", expected, name, err)
+		}
+	}
+
+	_, err := NewGitHubFetcher(cl, "test", "testing", "").FileContent(context.Background(), "limited.json")
+	if at, ok := RateLimitReset(err); !ok || !at.Equal(reset) {
+		t.Errorf("expected rate limit reset at %s, got: %s, %v", reset, at, err)
+	}
+	if _, ok := RateLimitReset(ErrFileNotFound); ok {
+		t.Errorf("expected no rate limit reset on not rate limit error")
+	}
+
+	_, err = NewGitHubFetcher(cl, "test", "testing", "").(FileLister).ListFiles(context.Background(), "**")
+	if !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("expected ref not found error on missing tree, got: %v", err)
+	}
+
+	// Network failure leaves no response
+	failing := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})}
+	_, err = NewGitHubFetcher(failing, "test", "testing", "").FileContent(context.Background(), "composer.json")
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("expected network error, got: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewGitHubFetcher(failing, "test", "testing", "").FileContent(ctx, "composer.json")
+	if err != context.Canceled {
+		t.Errorf("expected context error, got: %v", err)
+	}
+}
+
+func TestHTTPFile_Errors(t *testing.T) {
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/projects/group/app/repository/files/unauthorized.json/raw":
+			rw.WriteHeader(http.StatusUnauthorized)
+		case "/api/v4/projects/group/app/repository/files/limited.json/raw":
+			rw.Header().Set("Retry-After", "60")
+			rw.WriteHeader(http.StatusTooManyRequests)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	fetcher := NewGitLabFetcher(cl, nil, "group/app", "", "")
+
+	_, err := fetcher.FileContent(context.Background(), "unauthorized.json")
+	if !errors.Is(err, ErrUnauthorized) || err.Error() != "gitlab responded with HTTP error '401: Unauthorized'" {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+	_, err = fetcher.FileContent(context.Background(), "limited.json")
+	if at, ok := RateLimitReset(err); !errors.Is(err, ErrRateLimited) || !ok || time.Until(at) <= 0 {
+		t.Errorf("expected rate limit error with reset time, got: %v", err)
+	}
+
+	failing := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: "Get", URL: r.URL.String(), Err: errors.New("connection refused")}
+	})}
+	_, err = NewGitLabFetcher(failing, nil, "group/app", "", "").FileContent(context.Background(), "composer.json")
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("expected network error, got: %v", err)
+	}
+
+	// Requests rejected by the rate limit aware transport
+	reset := time.Now().Add(time.Hour)
+	blocked := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, &transport.RateLimitError{Host: r.URL.Host, Reset: reset}
+	})}
+	_, err = NewGitLabFetcher(blocked, nil, "group/app", "", "").FileContent(context.Background(), "composer.json")
+	if at, ok := RateLimitReset(err); !errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNetwork) || !ok || !at.Equal(reset) {
+		t.Errorf("expected rate limit error, got: %v", err)
+	}
+}
+
+// roundTripFunc is the http.RoundTripper function adapter.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,212 @@
+package fetchers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrGitNotFound is returned when git executable can't be found.
+var ErrGitNotFound = errors.New("git executable not found")
+
+// ErrInvalidRevision is returned when the revision can't be passed to git (e.g. it starts with '-').
+var ErrInvalidRevision = errors.New("invalid git revision")
+
+// GitCloneFetcher fetches files from the git object store of the local clone (plain git remotes without forge API).
+//
+// Remote repository is cloned (shallow and partial, blobs are fetched on demand) on the first call,
+// existing local bare repository or mirror can be opened with NewGitRepoFetcher instead.
+type GitCloneFetcher struct {
+	// Remote is the repository address (e.g. 'https://git.example.com/repo.git' or 'file:///srv/repo.git').
+	Remote string
+	// SHA can both refer to commit hash/branch/tag, empty value means remote HEAD.
+	SHA string
+	// Dir is the git directory of the clone, if empty - temporary directory is used (see Close).
+	Dir string
+	// GitBin is the git executable path, default is 'git' looked up in PATH.
+	GitBin string
+
+	mu      sync.Mutex
+	rev     string
+	tempDir bool
+}
+
+// NewGitCloneFetcher constructs GitCloneFetcher cloning the remote repository into the directory (or temporary directory if empty).
+func NewGitCloneFetcher(remote, sha, dir string) (*GitCloneFetcher, error) {
+	if err := validateRevision(sha); err != nil {
+		return nil, err
+	}
+	return &GitCloneFetcher{Remote: remote, SHA: sha, Dir: dir}, nil
+}
+
+// NewGitRepoFetcher constructs GitCloneFetcher reading files from the existing local repository (bare repository or mirror),
+// nothing is cloned or fetched.
+func NewGitRepoFetcher(dir, sha string) (*GitCloneFetcher, error) {
+	if err := validateRevision(sha); err != nil {
+		return nil, err
+	}
+	return &GitCloneFetcher{SHA: sha, Dir: dir}, nil
+}
+
+// FileContent reads specified file content from the configured revision.
+// Path argument is the root-related file path.
+func (p *GitCloneFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
+	if err := p.init(ctx); err != nil {
+		return nil, err
+	}
+
+	out, err := p.git(ctx, "--literal-pathspecs", "ls-tree", "-z", p.rev, "--", strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load '%s' file from git repository: %w", path, err)
+	}
+	if len(out) == 0 {
+		return nil, ErrFileNotFound
+	}
+	// Output format: '{mode} {type} {object}\t{path}'
+	fields := strings.Fields(string(bytes.SplitN(out, []byte("\t"), 2)[0]))
+	if len(fields) != 3 || fields[1] != "blob" {
+		return nil, fmt.Errorf("parameter is a directory or not a valid file")
+	}
+
+	b, err := p.git(ctx, "cat-file", "blob", fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("unable to load '%s' file from git repository: %w", path, err)
+	}
+	return b, nil
+}
+
+// ListFiles returns sorted root-related paths of the revision files matching the pattern.
+func (p *GitCloneFetcher) ListFiles(ctx context.Context, pattern string) ([]string, error) {
+	if err := p.init(ctx); err != nil {
+		return nil, err
+	}
+
+	out, err := p.git(ctx, "ls-tree", "-r", "-z", "--name-only", p.rev)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list git repository files: %w", err)
+	}
+	res := []string{}
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name != "" && MatchPattern(pattern, name) {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// Close removes temporary clone directory (if any).
+func (p *GitCloneFetcher) Close() error {
+	if !p.tempDir {
+		return nil
+	}
+	return os.RemoveAll(p.Dir)
+}
+
+// init clones the remote repository (if configured) and resolves the revision, it's done only once.
+//
+// Failed initialization (e.g. cancelled context) is not kept, it's retried on the next call.
+func (p *GitCloneFetcher) init(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.rev != "" {
+		return nil
+	}
+	if err := validateRevision(p.SHA); err != nil {
+		return err
+	}
+
+	if p.Remote != "" {
+		if err := p.clone(ctx); err != nil {
+			return err
+		}
+	}
+
+	ref := p.SHA
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if p.Remote != "" {
+		ref = "FETCH_HEAD"
+	}
+	out, err := p.git(ctx, "rev-parse", "--verify", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("unable to resolve git revision %q: %w", ref, err)
+	}
+	p.rev = strings.TrimSpace(string(out))
+	return nil
+}
+
+// clone fetches the single revision of the remote repository into the bare git directory.
+func (p *GitCloneFetcher) clone(ctx context.Context) error {
+	if p.Dir == "" {
+		dir, err := ioutil.TempDir("", "dephub-git")
+		if err != nil {
+			return fmt.Errorf("unable to create git clone directory: %w", err)
+		}
+		p.Dir, p.tempDir = dir, true
+	}
+
+	if _, err := p.git(ctx, "init", "--bare", "--quiet", p.Dir); err != nil {
+		return fmt.Errorf("unable to init git clone directory: %w", err)
+	}
+	// Blobs are fetched lazily from the promisor remote
+	for _, args := range [][]string{
+		{"config", "remote.origin.url", p.Remote},
+		{"config", "remote.origin.promisor", "true"},
+		{"config", "remote.origin.partialclonefilter", "blob:none"},
+	} {
+		if _, err := p.git(ctx, args...); err != nil {
+			return fmt.Errorf("unable to configure git clone directory: %w", err)
+		}
+	}
+
+	ref := p.SHA
+	if ref == "" {
+		ref = "HEAD"
+	}
+	// Shallow partial fetch of the requested revision only, it's stored as FETCH_HEAD
+	_, err := p.git(ctx, "fetch", "--quiet", "--depth=1", "--filter=blob:none", "--no-tags", "--end-of-options", "origin", ref)
+	if err != nil {
+		return fmt.Errorf("unable to fetch %q revision from %q git remote: %w", ref, p.Remote, err)
+	}
+	return nil
+}
+
+// validateRevision - helper to reject revisions git would read as an option.
+func validateRevision(sha string) error {
+	if strings.HasPrefix(sha, "-") {
+		return fmt.Errorf("%w: %q", ErrInvalidRevision, sha)
+	}
+	return nil
+}
+
+// git runs git command in the configured git directory and returns it's standard output.
+func (p *GitCloneFetcher) git(ctx context.Context, args ...string) ([]byte, error) {
+	bin := p.GitBin
+	if bin == "" {
+		bin = "git"
+	}
+	if _, err := exec.LookPath(bin); err != nil {
+		return nil, ErrGitNotFound
+	}
+	if args[0] != "init" {
+		args = append([]string{"--git-dir", p.Dir}, args...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,194 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// gitRepository creates temporary git repository with two commits, first one is tagged 'v1.0.0'.
+func gitRepository(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git executable not found")
+	}
+	dir := localTree(t, map[string]string{
+		"composer.json":              `{"require": {"php": "^7.4"}}`,
+		"services/api/composer.json": `{}`,
+	})
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+		}
+	}
+	run("init", "--quiet", "--initial-branch=main")
+	run("add", ".")
+	run("commit", "--quiet", "-m", "initial")
+	run("tag", "v1.0.0")
+	if err := ioutil.WriteFile(filepath.Join(dir, "composer.json"), []byte(`{"require": {"php": "^8.0"}}`), 0o644); err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+	run("commit", "--quiet", "-am", "php 8")
+	// Allow partial clones from the repository
+	run("config", "uploadpack.allowFilter", "true")
+	return dir
+}
+
+// gitFetcher - helper to construct GitCloneFetcher failing the test on error.
+func gitFetcher(t *testing.T, remote, sha, dir string) *GitCloneFetcher {
+	t.Helper()
+	var fetcher *GitCloneFetcher
+	var err error
+	if remote != "" {
+		fetcher, err = NewGitCloneFetcher(remote, sha, dir)
+	} else {
+		fetcher, err = NewGitRepoFetcher(dir, sha)
+	}
+	if err != nil {
+		t.Fatalf("unexpected error on fetcher construction: %v", err)
+	}
+	return fetcher
+}
+
+func TestGitCloneFetcher_FileContent(t *testing.T) {
+	repo := gitRepository(t)
+
+	cases := []struct {
+		Name     string
+		SHA      string
+		Expected string
+	}{
+		{"default branch", "", `{"require": {"php": "^8.0"}}`},
+		{"branch", "main", `{"require": {"php": "^8.0"}}`},
+		{"tag", "v1.0.0", `{"require": {"php": "^7.4"}}`},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Name, func(t *testing.T) {
+			fetcher := gitFetcher(t, "file://"+repo, cs.SHA, "")
+			defer fetcher.Close()
+
+			content, err := fetcher.FileContent(context.Background(), "composer.json")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(content) != cs.Expected {
+				t.Errorf("unexpected file content: %q", content)
+			}
+		})
+	}
+
+	fetcher := gitFetcher(t, "file://"+repo, "", "")
+	defer fetcher.Close()
+	if content, err := fetcher.FileContent(context.Background(), "missing.json"); err != ErrFileNotFound || content != nil {
+		t.Errorf("expected file not found error, got: %q, %v", content, err)
+	}
+	if _, err := fetcher.FileContent(context.Background(), "services"); err == nil || err.Error() != "parameter is a directory or not a valid file" {
+		t.Errorf("expected directory error, got: %v", err)
+	}
+	files, err := fetcher.ListFiles(context.Background(), "**/composer.json")
+	if err != nil {
+		t.Fatalf("unexpected error on files listing: %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"composer.json", "services/api/composer.json"}) {
+		t.Errorf("unexpected listed files: %+v", files)
+	}
+
+	dir := fetcher.Dir
+	if err := fetcher.Close(); err != nil {
+		t.Errorf("unexpected error on close: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected temporary clone directory to be removed, got: %v", err)
+	}
+
+	_, err = gitFetcher(t, "file://"+repo, "missing-branch", "").FileContent(context.Background(), "composer.json")
+	if err == nil || !strings.HasPrefix(err.Error(), `unable to fetch "missing-branch" revision from`) {
+		t.Errorf("expected fetch error on missing branch, got: %v", err)
+	}
+}
+
+func TestGitCloneFetcher_RetryInit(t *testing.T) {
+	repo := gitRepository(t)
+	fetcher := gitFetcher(t, "file://"+repo, "", "")
+	defer fetcher.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := fetcher.FileContent(ctx, "composer.json"); err == nil {
+		t.Fatalf("expected error on cancelled context, got none")
+	}
+
+	// Failed clone is retried with the next call context
+	content, err := fetcher.FileContent(context.Background(), "composer.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != `{"require": {"php": "^8.0"}}` {
+		t.Errorf("unexpected file content: %q", content)
+	}
+}
+
+func TestGitCloneFetcher_OptionRevision(t *testing.T) {
+	repo := gitRepository(t)
+	marker := filepath.Join(localTree(t, nil), "PWNED")
+	sha := "--upload-pack=touch " + marker + "; git-upload-pack"
+
+	if _, err := NewGitCloneFetcher("file://"+repo, sha, ""); !errors.Is(err, ErrInvalidRevision) {
+		t.Errorf("expected invalid revision error, got: %v", err)
+	}
+	if _, err := NewGitRepoFetcher(repo, sha); !errors.Is(err, ErrInvalidRevision) {
+		t.Errorf("expected invalid revision error, got: %v", err)
+	}
+
+	// Revision set directly is rejected before running git as well
+	fetcher := &GitCloneFetcher{Remote: "file://" + repo, SHA: sha}
+	defer fetcher.Close()
+	if _, err := fetcher.FileContent(context.Background(), "composer.json"); !errors.Is(err, ErrInvalidRevision) {
+		t.Errorf("expected invalid revision error, got: %v", err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("expected revision not to be run as git option, got: %v", err)
+	}
+}
+
+func TestGitRepoFetcher_FileContent(t *testing.T) {
+	repo := gitRepository(t)
+	mirror := filepath.Join(localTree(t, nil), "mirror.git")
+	if out, err := exec.Command("git", "clone", "--quiet", "--mirror", repo, mirror).CombinedOutput(); err != nil {
+		t.Fatalf("unable to mirror repository: %v: %s", err, out)
+	}
+
+	fetcher := gitFetcher(t, "", "v1.0.0", mirror)
+	content, err := fetcher.FileContent(context.Background(), "composer.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != `{"require": {"php": "^7.4"}}` {
+		t.Errorf("unexpected file content: %q", content)
+	}
+	if err := fetcher.Close(); err != nil {
+		t.Errorf("unexpected error on close: %v", err)
+	}
+	if _, err := os.Stat(mirror); err != nil {
+		t.Errorf("expected existing repository to be kept, got: %v", err)
+	}
+
+	_, err = gitFetcher(t, "", "v9.9.9", mirror).FileContent(context.Background(), "composer.json")
+	if err == nil || !strings.HasPrefix(err.Error(), `unable to resolve git revision "v9.9.9"`) {
+		t.Errorf("expected revision error, got: %v", err)
+	}
+
+	fetcher = gitFetcher(t, "", "", mirror)
+	fetcher.GitBin = "dephub-missing-git"
+	if _, err = fetcher.FileContent(context.Background(), "composer.json"); !errors.Is(err, ErrGitNotFound) {
+		t.Errorf("expected git not found error, got: %v", err)
+	}
+}
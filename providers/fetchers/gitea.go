@@ -0,0 +1,101 @@
+package fetchers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// giteaAPIURL - codeberg.org (public Forgejo instance) API base url (used as default GiteaFetcher baseURL)
+var giteaAPIURL *url.URL
+
+// giteaAPIHostname - codeberg.org API v1 address.
+var giteaAPIHostname string = "https://codeberg.org/api/v1/"
+
+func init() {
+	giteaAPIURL, _ = url.Parse(giteaAPIHostname)
+}
+
+// GiteaFetcher fetches files from the specified Gitea or Forgejo repository.
+// Owner and Repo represent '{owner}/{repo}' notation.
+type GiteaFetcher struct {
+	Owner string
+	Repo  string
+	SHA   string
+	// Token is the access token, sent with 'Authorization' header (if not empty).
+	Token      string
+	baseURL    url.URL
+	httpClient *http.Client
+}
+
+// giteaContent represents Gitea contents API file response.
+type giteaContent struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// NewGiteaFetcher constructs GiteaFetcher with specified parameters.
+//
+// If httpClient or baseURL is nil - default values will be used (codeberg.org API v1).
+// Pass baseURL of the self-hosted instance API (e.g. 'https://gitea.example.com/api/v1/').
+func NewGiteaFetcher(httpClient *http.Client, baseURL *url.URL, owner, repo, sha, token string) FileFetcher {
+	if baseURL == nil {
+		baseURL = giteaAPIURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GiteaFetcher{Owner: owner, Repo: repo, SHA: sha, Token: token, baseURL: *baseURL, httpClient: httpClient}
+}
+
+// FileContent fetches specified file content from the configured repository.
+// Path argument is the root-related file path.
+func (p GiteaFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
+	route := fmt.Sprintf(
+		"%s/repos/%s/%s/contents/%s",
+		strings.TrimSuffix(p.baseURL.String(), "/"),
+		url.PathEscape(p.Owner),
+		url.PathEscape(p.Repo),
+		escapePath(path),
+	)
+	if p.SHA != "" {
+		route += "?ref=" + url.QueryEscape(p.SHA)
+	}
+
+	header := http.Header{}
+	if p.Token != "" {
+		header.Set("Authorization", "token "+p.Token)
+	}
+	b, err := httpFile(ctx, p.httpClient, "gitea", route, path, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var content giteaContent
+	if err = json.Unmarshal(b, &content); err != nil || content.Type != "file" {
+		return nil, fmt.Errorf("parameter is a directory or not a valid file")
+	}
+	if content.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported gitea content encoding %q", content.Encoding)
+	}
+	c, err := base64.StdEncoding.DecodeString(content.Content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode gitea file content: %w", err)
+	}
+	return c, nil
+}
+
+// CacheKey returns the repository file cache key (e.g. 'codeberg.org/owner/repo@ref:path').
+func (p GiteaFetcher) CacheKey(path string) string {
+	return fmt.Sprintf("%s/%s@%s:%s", p.baseURL.Host, p.Owner+"/"+p.Repo, p.SHA, path)
+}
+
+// Revision returns the fetched revision (commit hash/branch/tag).
+func (p GiteaFetcher) Revision() string {
+	return p.SHA
+}
@@ -0,0 +1,49 @@
+package fetchers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGiteaFetcher_FileContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.RequestURI() {
+		case "/api/v1/repos/org/repo/contents/services/requirements.txt?ref=main":
+			_, _ = rw.Write([]byte(`{"type": "file", "encoding": "base64", "content": "RGphbmdvPT0zLjIuOQ=="}`))
+		case "/api/v1/repos/org/repo/contents/services":
+			_, _ = rw.Write([]byte(`[{"type": "file", "name": "requirements.txt"}]`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	baseURL, _ := url.Parse(srv.URL + "/api/v1/")
+
+	content, err := NewGiteaFetcher(srv.Client(), baseURL, "org", "repo", "main", "secret").FileContent(context.Background(), "services/requirements.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "Django==3.2.9" {
+		t.Errorf("unexpected file content: %q", content)
+	}
+
+	fetcher := NewGiteaFetcher(srv.Client(), baseURL, "org", "repo", "", "secret")
+	if content, err = fetcher.FileContent(context.Background(), "missing.txt"); err != ErrFileNotFound || content != nil {
+		t.Errorf("expected file not found error, got: %q, %v", content, err)
+	}
+	if _, err = fetcher.FileContent(context.Background(), "services"); err == nil || err.Error() != "parameter is a directory or not a valid file" {
+		t.Errorf("expected directory error, got: %v", err)
+	}
+
+	defaults := NewGiteaFetcher(nil, nil, "org", "repo", "", "").(*GiteaFetcher)
+	if defaults.httpClient != http.DefaultClient || defaults.baseURL.String() != "https://codeberg.org/api/v1/" {
+		t.Errorf("expected default http client and base url, got: %+v", defaults)
+	}
+}
@@ -8,10 +8,16 @@ Usage:
 package fetchers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v33/github"
 )
@@ -20,6 +26,9 @@ var (
 	ErrFileNotFound = errors.New("dependency file not found")
 )
 
+// githubMaxFileSize is the GitHub API file size limit, larger files can't be fetched via API.
+const githubMaxFileSize = 100 << 20
+
 // FileFetcher interface defines fetchers methods.
 type FileFetcher interface {
 	FileContent(ctx context.Context, path string) ([]byte, error)
@@ -39,27 +48,67 @@ func (sf ByteMapFetcher) FileContent(ctx context.Context, path string) ([]byte,
 	return v, nil
 }
 
+// ListFiles returns sorted paths of the stored files matching the pattern.
+func (sf ByteMapFetcher) ListFiles(ctx context.Context, pattern string) ([]string, error) {
+	res := []string{}
+	for name := range sf.Files {
+		if MatchPattern(pattern, name) {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
 // GitHubFetcher fetches files from the specified repository.
 // Owner and Repo represent '{owner}/{repo}' notation.
 // httpClient can be used as OAuth2 or BasicAuth http transport.
+//
+// Files over 1 MB are not returned by the contents API, they are downloaded via the git blobs API
+// (or the raw download URL) within MaxFileSize limit.
 type GitHubFetcher struct {
-	Owner        string
-	Repo         string
-	SHA          string
+	Owner string
+	Repo  string
+	SHA   string
+	// MaxFileSize is the file size limit in bytes, zero or negative value means no limit (GitHub API allows up to 100 MB).
+	MaxFileSize  int64
 	githubClient *github.Client
 }
 
-// NewGitHubFetcher constructs GitHubFileFetcher with specified parameters.
+// NewGitHubFetcher constructs GitHubFileFetcher with specified parameters, files size is limited by DefaultMaxFileSize.
 // httpClient can be used as OAuth2 or BasicAuth http transport.
 func NewGitHubFetcher(httpClient *http.Client, owner, repo, sha string) FileFetcher {
 	return &GitHubFetcher{
 		Owner:        owner,
 		Repo:         repo,
 		SHA:          sha,
+		MaxFileSize:  DefaultMaxFileSize,
 		githubClient: github.NewClient(httpClient),
 	}
 }
 
+// NewGitHubEnterpriseFetcher constructs GitHubFileFetcher for the GitHub Enterprise Server repository.
+//
+// baseURL and uploadURL are the enterprise instance addresses (e.g. 'https://ghe.corp/'),
+// '/api/v3/' and '/api/uploads/' suffixes are added if missing. If uploadURL is empty - baseURL is used instead.
+// httpClient can be used as OAuth2 or BasicAuth http transport.
+func NewGitHubEnterpriseFetcher(httpClient *http.Client, baseURL, uploadURL, owner, repo, sha string) (FileFetcher, error) {
+	if uploadURL == "" {
+		uploadURL = baseURL
+	}
+	client, err := github.NewEnterpriseClient(baseURL, uploadURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create github enterprise client: %w", err)
+	}
+	return &GitHubFetcher{
+		Owner:        owner,
+		Repo:         repo,
+		SHA:          sha,
+		MaxFileSize:  DefaultMaxFileSize,
+		githubClient: client,
+	}, nil
+}
+
 // FileContent fetches specified file content from the configured repository.
 // Path argument is the root-related file path.
 func (p GitHubFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
@@ -69,17 +118,249 @@ func (p GitHubFetcher) FileContent(ctx context.Context, path string) ([]byte, er
 
 	rc, dc, resp, err := p.githubClient.Repositories.GetContents(ctx, p.Owner, p.Repo, path, &opts)
 	if err != nil {
-		if resp.StatusCode == http.StatusNotFound {
-			return nil, ErrFileNotFound
-		}
-		return nil, fmt.Errorf("unable to load '%s' file from github: %w", path, err)
+		return nil, githubError(ctx, path, resp, err)
 	}
 
 	if len(dc) != 0 {
 		return nil, fmt.Errorf("parameter is a directory or not a valid file")
 	}
 
-	c, err := rc.GetContent()
+	return p.content(ctx, path, rc)
+}
+
+// content - helper to get the contents API file content, large files (without the content) are downloaded separately.
+func (p GitHubFetcher) content(ctx context.Context, path string, rc *github.RepositoryContent) ([]byte, error) {
+	size := int64(rc.GetSize())
+	if p.MaxFileSize > 0 && size > p.MaxFileSize {
+		return nil, &FileTooLargeError{Path: path, Size: size, Limit: p.MaxFileSize}
+	}
+	if rc.GetEncoding() != "none" && (rc.Content != nil && *rc.Content != "" || size == 0) {
+		c, err := rc.GetContent()
+		return []byte(c), err
+	}
+
+	// Files over 1 MB are returned without the content
+	var err error
+	if rc.GetSHA() != "" {
+		var b []byte
+		u := fmt.Sprintf("repos/%s/%s/git/blobs/%s", p.Owner, p.Repo, rc.GetSHA())
+		b, err = p.download(ctx, path, u, "application/vnd.github.v3.raw")
+		if err == nil || errors.Is(err, ErrFileTooLarge) {
+			return b, err
+		}
+	}
+	if rc.GetDownloadURL() != "" {
+		return p.download(ctx, path, rc.GetDownloadURL(), "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("unable to load '%s' file from github: file content is not available", path)
+}
+
+// download - helper to download the raw file content within the size limit.
+func (p GitHubFetcher) download(ctx context.Context, path, u, accept string) ([]byte, error) {
+	req, err := p.githubClient.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	buf := &limitedBuffer{limit: p.MaxFileSize}
+	resp, err := p.githubClient.Do(ctx, req, buf)
+	if buf.exceeded {
+		return nil, &FileTooLargeError{Path: path, Limit: p.MaxFileSize}
+	}
+	if err != nil {
+		return nil, githubError(ctx, path, resp, err)
+	}
+	return buf.buf.Bytes(), nil
+}
+
+// limitedBuffer is the writer buffering up to the limit bytes (zero or negative value means no limit),
+// writes over the limit fail, so the response is not read any further.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int64
+	exceeded bool
+}
+
+// Write appends the data to the buffer unless it exceeds the limit.
+func (lb *limitedBuffer) Write(b []byte) (int, error) {
+	if lb.limit > 0 && int64(lb.buf.Len()+len(b)) > lb.limit {
+		lb.exceeded = true
+		return 0, ErrFileTooLarge
+	}
+	return lb.buf.Write(b)
+}
+
+// githubError - helper to convert GitHub API error into ErrFileNotFound, FileTooLargeError or FetchError.
+//
+// Errors not matching any fetching error kind are wrapped as is.
+func githubError(ctx context.Context, path string, resp *github.Response, err error) error {
+	if resp == nil || resp.Response == nil {
+		return networkError(ctx, "github", path, err)
+	}
+
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return &FetchError{Provider: "github", Path: path, StatusCode: resp.StatusCode, Reset: rateErr.Rate.Reset.Time, Kind: ErrRateLimited, Err: err}
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		fe := &FetchError{Provider: "github", Path: path, StatusCode: resp.StatusCode, Kind: ErrRateLimited, Err: err}
+		if abuseErr.RetryAfter != nil {
+			fe.Reset = time.Now().Add(*abuseErr.RetryAfter)
+		}
+		return fe
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && strings.HasPrefix(errResp.Message, "No commit found for the ref") {
+			return &FetchError{Provider: "github", Path: path, StatusCode: resp.StatusCode, Kind: ErrRefNotFound, Err: err}
+		}
+		return ErrFileNotFound
+	}
+	if isTooLargeError(err) {
+		return &FileTooLargeError{Path: path, Limit: githubMaxFileSize}
+	}
+	if fe := statusError("github", path, resp.Response, err); fe.Kind != nil {
+		return fe
+	}
+	return fmt.Errorf("unable to load '%s' file from github: %w", path, err)
+}
+
+// treeError - helper to convert GitHub git trees API error, missing tree means missing repository or revision.
+func treeError(ctx context.Context, resp *github.Response, err error) error {
+	if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+		err = &FetchError{Provider: "github", StatusCode: resp.StatusCode, Kind: ErrRefNotFound, Err: err}
+	} else {
+		err = githubError(ctx, "", resp, err)
+	}
+	return fmt.Errorf("unable to load repository tree from github: %w", err)
+}
+
+// isTooLargeError reports whether the GitHub API refused to return the file because of its size (over 100 MB).
+func isTooLargeError(err error) bool {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	for _, e := range errResp.Errors {
+		if e.Code == "too_large" {
+			return true
+		}
+	}
+	return false
+}
+
+// ListFiles returns sorted root-related paths of the repository files matching the pattern.
+//
+// The whole repository tree is fetched with a single git trees API call, if GitHub truncates
+// the response (huge repositories) - the tree is walked directory by directory.
+func (p GitHubFetcher) ListFiles(ctx context.Context, pattern string) ([]string, error) {
+	ref := p.SHA
+	if ref == "" {
+		ref = "HEAD"
+	}
+	tree, resp, err := p.githubClient.Git.GetTree(ctx, p.Owner, p.Repo, ref, true)
+	if err != nil {
+		return nil, treeError(ctx, resp, err)
+	}
+
+	var files []string
+	if tree.GetTruncated() {
+		files, err = p.walkTree(ctx, ref, "")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "blob" {
+				files = append(files, entry.GetPath())
+			}
+		}
+	}
+
+	res := []string{}
+	for _, name := range files {
+		if MatchPattern(pattern, name) {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// walkTree - helper to list the tree files recursively without the recursive git trees API call.
+func (p GitHubFetcher) walkTree(ctx context.Context, sha, prefix string) ([]string, error) {
+	tree, resp, err := p.githubClient.Git.GetTree(ctx, p.Owner, p.Repo, sha, false)
+	if err != nil {
+		return nil, treeError(ctx, resp, err)
+	}
+
+	var res []string
+	for _, entry := range tree.Entries {
+		name := prefix + entry.GetPath()
+		switch entry.GetType() {
+		case "blob":
+			res = append(res, name)
+		case "tree":
+			files, err := p.walkTree(ctx, entry.GetSHA(), name+"/")
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, files...)
+		}
+	}
+	return res, nil
+}
+
+// FileContentETag fetches specified file content unless it's ETag matches the passed one (conditional request),
+// notModified is true if it does. Pass empty etag to fetch the content unconditionally.
+func (p GitHubFetcher) FileContentETag(ctx context.Context, path, etag string) ([]byte, string, bool, error) {
+	escapedPath := (&url.URL{Path: strings.TrimSuffix(path, "/")}).String()
+	u := fmt.Sprintf("repos/%s/%s/contents/%s", p.Owner, p.Repo, escapedPath)
+	if p.SHA != "" {
+		u += "?ref=" + url.QueryEscape(p.SHA)
+	}
+	req, err := p.githubClient.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("unable to create a request: %w", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	var rawJSON json.RawMessage
+	resp, err := p.githubClient.Do(ctx, req, &rawJSON)
+	if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotModified {
+		return nil, etag, true, nil
+	}
+	if err != nil {
+		return nil, "", false, githubError(ctx, path, resp, err)
+	}
+
+	var rc github.RepositoryContent
+	if err = json.Unmarshal(rawJSON, &rc); err != nil {
+		return nil, "", false, fmt.Errorf("parameter is a directory or not a valid file")
+	}
+	c, err := p.content(ctx, path, &rc)
+	if err != nil {
+		return nil, "", false, err
+	}
+	return c, resp.Header.Get("ETag"), false, nil
+}
+
+// CacheKey returns the repository file cache key (e.g. 'api.github.com/owner/repo@ref:path').
+func (p GitHubFetcher) CacheKey(path string) string {
+	return fmt.Sprintf("%s/%s/%s@%s:%s", p.githubClient.BaseURL.Host, p.Owner, p.Repo, p.SHA, path)
+}
 
-	return []byte(c), err
+// Revision returns the fetched revision (commit hash/branch/tag).
+func (p GitHubFetcher) Revision() string {
+	return p.SHA
 }
@@ -3,10 +3,13 @@ package fetchers
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -45,6 +48,160 @@ func TestFetchContentMethod(t *testing.T) {
 	}
 }
 
+func TestFetchContentMethod_Enterprise(t *testing.T) {
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Host != "ghe.corp" || r.URL.Path != "/api/v3/repos/team/app/contents/composer.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write([]byte(`{
+			"content" : "Hello enterprise!"
+		}`))
+	}))
+
+	expected := "Hello enterprise!"
+
+	fetcher, err := NewGitHubEnterpriseFetcher(cl, "https://ghe.corp/", "", "team", "app", "")
+	if err != nil {
+		t.Fatalf("unexpected error on enterprise fetcher construction: %v", err)
+	}
+	content, err := fetcher.FileContent(context.Background(), "composer.json")
+	if err != nil {
+		t.Error(err)
+	}
+	if string(content) != expected {
+		t.Errorf("expected content '%s', got '%s'", expected, string(content))
+	}
+
+	fetcher, err = NewGitHubEnterpriseFetcher(cl, "://ghe.corp", "", "team", "app", "")
+	if err == nil || fetcher != nil {
+		t.Errorf("expected error on invalid enterprise url, got: %+v, %v", fetcher, err)
+	}
+}
+
+func TestFetchContentMethod_LargeFile(t *testing.T) {
+	large := strings.Repeat("x", 2<<20)
+	blobRequests := 0
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/test/testing/contents/composer.lock":
+			_, _ = rw.Write([]byte(`{"encoding": "none", "content": "", "size": 2097152, "sha": "abc",
+				"download_url": "https://raw.githubusercontent.com/test/testing/main/composer.lock"}`))
+		case "/repos/test/testing/contents/package-lock.json":
+			_, _ = rw.Write([]byte(`{"encoding": "none", "content": "", "size": 2097152,
+				"download_url": "https://raw.githubusercontent.com/test/testing/main/package-lock.json"}`))
+		case "/repos/test/testing/contents/huge.json":
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"message": "This API returns blobs up to 1 MB in size.",
+				"errors": [{"resource": "Blob", "field": "data", "code": "too_large"}]}`))
+		case "/repos/test/testing/git/blobs/abc":
+			blobRequests++
+			if r.Header.Get("Accept") != "application/vnd.github.v3.raw" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = rw.Write([]byte(large))
+		case "/test/testing/main/package-lock.json":
+			if r.Host != "raw.githubusercontent.com" {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = rw.Write([]byte(large))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	fetcher := NewGitHubFetcher(cl, "test", "testing", "main").(*GitHubFetcher)
+	for _, name := range []string{"composer.lock", "package-lock.json"} {
+		content, err := fetcher.FileContent(context.Background(), name)
+		if err != nil || string(content) != large {
+			t.Errorf("unexpected %s content (%d bytes): %v", name, len(content), err)
+		}
+	}
+	if blobRequests != 1 {
+		t.Errorf("expected blob to be fetched once, got %d requests", blobRequests)
+	}
+
+	// Declared size exceeds the limit, nothing is downloaded
+	fetcher.MaxFileSize = 1 << 20
+	_, err := fetcher.FileContent(context.Background(), "composer.lock")
+	var tooLarge *FileTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Size != 2<<20 || tooLarge.Limit != 1<<20 || blobRequests != 1 {
+		t.Errorf("expected file too large error with the file size, got: %v", err)
+	}
+
+	_, err = fetcher.FileContent(context.Background(), "huge.json")
+	if !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("expected file too large error on API refusal, got: %v", err)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 5}
+	n, err := io.Copy(buf, strings.NewReader("0123456789"))
+	if err == nil || !buf.exceeded || n != 0 {
+		t.Errorf("expected write over the limit to fail, got: %d, %v", n, err)
+	}
+
+	buf = &limitedBuffer{}
+	if _, err = io.Copy(buf, strings.NewReader("0123456789")); err != nil || buf.buf.String() != "0123456789" {
+		t.Errorf("unexpected unlimited buffer result: %q, %v", buf.buf.String(), err)
+	}
+}
+
+func TestListFilesMethod(t *testing.T) {
+	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/repos/test/testing/git/trees/main?recursive=1":
+			_, _ = rw.Write([]byte(`{"sha": "root", "truncated": false, "tree": [
+				{"path": "composer.json", "type": "blob"},
+				{"path": "services", "type": "tree"},
+				{"path": "services/api", "type": "tree"},
+				{"path": "services/api/composer.json", "type": "blob"},
+				{"path": "services/api/README.md", "type": "blob"}
+			]}`))
+		case "/repos/test/testing/git/trees/HEAD?recursive=1":
+			_, _ = rw.Write([]byte(`{"sha": "root", "truncated": true, "tree": []}`))
+		case "/repos/test/testing/git/trees/HEAD":
+			_, _ = rw.Write([]byte(`{"sha": "root", "tree": [
+				{"path": "composer.json", "type": "blob"},
+				{"path": "services", "type": "tree", "sha": "services-sha"}
+			]}`))
+		case "/repos/test/testing/git/trees/services-sha":
+			_, _ = rw.Write([]byte(`{"sha": "services-sha", "tree": [{"path": "composer.json", "type": "blob"}]}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	cases := []struct {
+		Name     string
+		SHA      string
+		Expected []string
+	}{
+		{"recursive", "main", []string{"composer.json", "services/api/composer.json"}},
+		{"truncated", "", []string{"composer.json", "services/composer.json"}},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Name, func(t *testing.T) {
+			fetcher := NewGitHubFetcher(cl, "test", "testing", cs.SHA).(FileLister)
+			files, err := fetcher.ListFiles(context.Background(), "**/composer.json")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(files, cs.Expected) {
+				t.Errorf("unexpected listed files: %+v", files)
+			}
+		})
+	}
+
+	_, err := NewGitHubFetcher(cl, "test", "missing", "").(FileLister).ListFiles(context.Background(), "**")
+	if err == nil {
+		t.Errorf("expected error on missing repository tree, got none")
+	}
+}
+
 func TestFetchContentMethod_HttpNotFound(t *testing.T) {
 	cl := configureClient(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		rw.WriteHeader(http.StatusNotFound)
@@ -125,3 +282,26 @@ func TestByteMapFetcher(t *testing.T) {
 		t.Errorf("expected nil result on unknown file, got '%-v'", result)
 	}
 }
+
+func TestByteMapFetcher_ListFiles(t *testing.T) {
+	fetcher := ByteMapFetcher{Files: map[string][]byte{
+		"composer.json":              []byte("{}"),
+		"services/api/composer.json": []byte("{}"),
+		"services/api/composer.lock": []byte("{}"),
+		"services/web/composer.json": []byte("{}"),
+	}}
+
+	result, err := fetcher.ListFiles(context.Background(), "**/composer.json")
+	if err != nil {
+		t.Errorf("unexpected error on byte map files listing: %v", err)
+	}
+	expected := []string{"composer.json", "services/api/composer.json", "services/web/composer.json"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("unexpected byte map files listing result: %+v", result)
+	}
+
+	result, err = fetcher.ListFiles(context.Background(), "unknown/*")
+	if err != nil || len(result) != 0 {
+		t.Errorf("expected empty listing result, got: %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,81 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// gitlabAPIURL - gitlab.com API base url (used as default GitLabFetcher baseURL)
+var gitlabAPIURL *url.URL
+
+// gitlabAPIHostname - gitlab.com API v4 address.
+var gitlabAPIHostname string = "https://gitlab.com/api/v4/"
+
+func init() {
+	gitlabAPIURL, _ = url.Parse(gitlabAPIHostname)
+}
+
+// GitLabFetcher fetches files from the specified GitLab (gitlab.com or self-hosted) repository.
+// Project is the full repository path including nested groups (e.g. 'group/subgroup/repo').
+type GitLabFetcher struct {
+	Project string
+	SHA     string
+	// Token is the private/personal access token, sent with 'PRIVATE-TOKEN' header (if not empty).
+	Token      string
+	baseURL    url.URL
+	httpClient *http.Client
+}
+
+// NewGitLabFetcher constructs GitLabFetcher with specified parameters.
+//
+// If httpClient or baseURL is nil - default values will be used (gitlab.com API v4).
+// Pass baseURL of the self-hosted instance API (e.g. 'https://gitlab.example.com/api/v4/').
+func NewGitLabFetcher(httpClient *http.Client, baseURL *url.URL, project, sha, token string) FileFetcher {
+	if baseURL == nil {
+		baseURL = gitlabAPIURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GitLabFetcher{
+		Project:    project,
+		SHA:        sha,
+		Token:      token,
+		baseURL:    *baseURL,
+		httpClient: httpClient,
+	}
+}
+
+// FileContent fetches specified file content from the configured repository.
+// Path argument is the root-related file path.
+func (p GitLabFetcher) FileContent(ctx context.Context, path string) ([]byte, error) {
+	ref := p.SHA
+	if ref == "" {
+		ref = "HEAD"
+	}
+	route := fmt.Sprintf(
+		"%s/projects/%s/repository/files/%s/raw?ref=%s",
+		strings.TrimSuffix(p.baseURL.String(), "/"),
+		url.PathEscape(p.Project),
+		url.PathEscape(strings.TrimPrefix(path, "/")),
+		url.QueryEscape(ref),
+	)
+	header := http.Header{}
+	if p.Token != "" {
+		header.Set("PRIVATE-TOKEN", p.Token)
+	}
+	return httpFile(ctx, p.httpClient, "gitlab", route, path, header)
+}
+
+// CacheKey returns the repository file cache key (e.g. 'gitlab.com/group/repo@ref:path').
+func (p GitLabFetcher) CacheKey(path string) string {
+	return fmt.Sprintf("%s/%s@%s:%s", p.baseURL.Host, p.Project, p.SHA, path)
+}
+
+// Revision returns the fetched revision (commit hash/branch/tag).
+func (p GitLabFetcher) Revision() string {
+	return p.SHA
+}
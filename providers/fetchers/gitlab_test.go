@@ -0,0 +1,71 @@
+package fetchers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGitLabFetcher_FileContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.RequestURI() {
+		case "/api/v4/projects/group%2Fsub%2Frepo/repository/files/services%2Fapi%2Fcomposer.json/raw?ref=main":
+			_, _ = rw.Write([]byte(`{"require": {}}`))
+		case "/api/v4/projects/group%2Fsub%2Frepo/repository/files/composer.json/raw?ref=HEAD":
+			_, _ = rw.Write([]byte(`{}`))
+		case "/api/v4/projects/group%2Fsub%2Frepo/repository/files/broken.json/raw?ref=main":
+			rw.WriteHeader(http.StatusInternalServerError)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	baseURL, _ := url.Parse(srv.URL + "/api/v4/")
+
+	fetcher := NewGitLabFetcher(srv.Client(), baseURL, "group/sub/repo", "main", "secret")
+	content, err := fetcher.FileContent(context.Background(), "services/api/composer.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != `{"require": {}}` {
+		t.Errorf("unexpected file content: %q", content)
+	}
+
+	content, err = fetcher.FileContent(context.Background(), "missing.json")
+	if err != ErrFileNotFound || content != nil {
+		t.Errorf("expected file not found error, got: %q, %v", content, err)
+	}
+
+	_, err = fetcher.FileContent(context.Background(), "broken.json")
+	if err == nil || err.Error() != "gitlab responded with HTTP error '500: Internal Server Error'" {
+		t.Errorf("expected HTTP error, got: %v", err)
+	}
+
+	// Default ref
+	content, err = NewGitLabFetcher(srv.Client(), baseURL, "group/sub/repo", "", "secret").FileContent(context.Background(), "composer.json")
+	if err != nil || string(content) != "{}" {
+		t.Errorf("unexpected result on default ref: %q, %v", content, err)
+	}
+
+	// No token
+	_, err = NewGitLabFetcher(srv.Client(), baseURL, "group/sub/repo", "main", "").FileContent(context.Background(), "composer.json")
+	if err == nil {
+		t.Errorf("expected HTTP error without token, got none")
+	}
+}
+
+func TestGitLabFetcher_Defaults(t *testing.T) {
+	fetcher := NewGitLabFetcher(nil, nil, "group/repo", "", "").(*GitLabFetcher)
+	if fetcher.httpClient != http.DefaultClient {
+		t.Errorf("expected default http client, got: %+v", fetcher.httpClient)
+	}
+	if fetcher.baseURL.String() != "https://gitlab.com/api/v4/" {
+		t.Errorf("expected default gitlab.com base url, got: %q", fetcher.baseURL.String())
+	}
+}
@@ -0,0 +1,57 @@
+package fetchers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// httpFile - helper to fetch file from git hosting HTTP API, 404 response is treated as ErrFileNotFound,
+// other failures are returned as FetchError.
+//
+// Provider is the hosting name used in errors (e.g. 'gitlab'), header contains request headers (e.g. authorization).
+func httpFile(ctx context.Context, httpClient *http.Client, provider, route, path string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", route, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, networkError(ctx, provider, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFileNotFound
+	}
+	if resp.StatusCode >= 400 {
+		return nil, statusError(provider, path, resp, nil)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the response body: %w", err)
+	}
+	return b, nil
+}
+
+// escapePath - helper to escape every segment of the slash separated path.
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// basicAuth - helper to encode 'username:password' credentials for the 'Authorization: Basic' header.
+func basicAuth(credentials string) string {
+	return base64.StdEncoding.EncodeToString([]byte(credentials))
+}
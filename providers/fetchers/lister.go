@@ -0,0 +1,68 @@
+package fetchers
+
+import (
+	"context"
+	"path"
+	"strings"
+)
+
+// FileLister interface defines fetchers capable of listing source files.
+type FileLister interface {
+	// ListFiles returns sorted root-related paths of the files matching the pattern (see MatchPattern).
+	ListFiles(ctx context.Context, pattern string) ([]string, error)
+}
+
+// MatchPattern reports whether the slash separated file path matches the pattern.
+//
+// Pattern segments follow path.Match syntax, and '**' segment matches any number
+// of directories (including zero), e.g. 'services/**/composer.json' matches both
+// 'services/composer.json' and 'services/api/v1/composer.json'.
+func MatchPattern(pattern, name string) bool {
+	return matchSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(name, "/"), "/"))
+}
+
+// matchSegments matches path segments against pattern segments.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// matchDirPrefix reports whether files of the slash separated directory path can match the pattern.
+func matchDirPrefix(pattern, dir string) bool {
+	pttrn, segments := strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(dir, "/"), "/")
+	for len(segments) > 0 {
+		if len(pttrn) == 0 {
+			return false
+		}
+		if pttrn[0] == "**" {
+			return true
+		}
+		if ok, err := path.Match(pttrn[0], segments[0]); err != nil || !ok {
+			return false
+		}
+		pttrn, segments = pttrn[1:], segments[1:]
+	}
+	// The last pattern segment is the file name
+	return len(pttrn) > 0
+}
+
+// IsPattern reports whether the path contains any of the pattern special characters.
+func IsPattern(name string) bool {
+	return strings.ContainsAny(name, `*?[\`)
+}
@@ -0,0 +1,61 @@
+package fetchers
+
+import "testing"
+
+func TestMatchPattern(t *testing.T) {
+	// Table test
+	cases := []struct {
+		Pattern string
+		Name    string
+		Result  bool
+	}{
+		{"composer.json", "composer.json", true},
+		{"composer.json", "api/composer.json", false},
+		{"**/composer.json", "composer.json", true},
+		{"**/composer.json", "services/api/composer.json", true},
+		{"**/composer.json", "services/api/composer.lock", false},
+		{"services/*/composer.json", "services/api/composer.json", true},
+		{"services/*/composer.json", "services/api/v1/composer.json", false},
+		{"services/**/composer.json", "services/composer.json", true},
+		{"apps/*/requirements/*.txt", "apps/web/requirements/dev.txt", true},
+		{"apps/*/requirements/*.txt", "apps/web/requirements.txt", false},
+		{"**", "any/file.txt", true},
+		{"**/gradle/*.toml", "app/gradle/libs.versions.toml", true},
+		{"[", "[", false},
+	}
+
+	for _, cs := range cases {
+		if MatchPattern(cs.Pattern, cs.Name) != cs.Result {
+			t.Errorf("unexpected pattern %q match result for %q, expected %t", cs.Pattern, cs.Name, cs.Result)
+		}
+	}
+
+	if !IsPattern("requirements/*.txt") || IsPattern("requirements/dev.txt") {
+		t.Errorf("unexpected IsPattern results")
+	}
+}
+
+func TestMatchDirPrefix(t *testing.T) {
+	cases := []struct {
+		Pattern string
+		Dir     string
+		Result  bool
+	}{
+		{"composer.json", "services", false},
+		{"**/composer.json", "services/api", true},
+		{"services/*/composer.json", "services", true},
+		{"services/*/composer.json", "services/api", true},
+		{"services/*/composer.json", "services/api/v1", false},
+		{"services/*/composer.json", "apps", false},
+		{"services/**/composer.json", "services/api/v1", true},
+		{"apps/*/requirements/*.txt", "apps/web/requirements", true},
+		{"apps/*/requirements/*.txt", "apps/web/src", false},
+		{"[", "[", false},
+	}
+
+	for _, cs := range cases {
+		if matchDirPrefix(cs.Pattern, cs.Dir) != cs.Result {
+			t.Errorf("unexpected pattern %q directory match result for %q, expected %t", cs.Pattern, cs.Dir, cs.Result)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxFileSize is the default LocalFetcher file size limit (10 MiB).
+const DefaultMaxFileSize = 10 << 20
+
+// vcsDirs - version control directories names, they are never listed.
+var vcsDirs = []string{".git", ".hg", ".svn"}
+
+var (
+	// ErrPathOutsideRoot is returned when the requested path leaves the fetcher root directory.
+	ErrPathOutsideRoot = errors.New("file path is outside of the root directory")
+	// ErrSymlinkNotAllowed is returned when the requested path is a symbolic link forbidden by the fetcher policy.
+	ErrSymlinkNotAllowed = errors.New("symbolic link is not allowed")
+)
+
+// SymlinkPolicy defines how LocalFetcher treats symbolic links.
+type SymlinkPolicy int
+
+// Available symbolic links policies
+const (
+	// SymlinksWithinRoot follows symbolic links only if their targets are inside the root directory (default).
+	SymlinksWithinRoot = SymlinkPolicy(iota)
+	// SymlinksDeny forbids any symbolic links.
+	SymlinksDeny
+	// SymlinksFollow follows any symbolic links (even if their targets are outside of the root directory).
+	SymlinksFollow
+)
+
+// LocalFetcher fetches files from the local directory (e.g. repository working copy).
+type LocalFetcher struct {
+	// Root is the directory files paths are related to.
+	Root string
+	// Symlinks is symbolic links policy.
+	Symlinks SymlinkPolicy
+	// MaxFileSize is the file size limit in bytes, zero or negative value means no limit.
+	MaxFileSize int64
+}
+
+// NewLocalFetcher constructs LocalFetcher rooted at the specified directory,
+// symbolic links are followed within the root and files size is limited by DefaultMaxFileSize.
+func NewLocalFetcher(root string) FileFetcher {
+	return &LocalFetcher{
+		Root:        root,
+		Symlinks:    SymlinksWithinRoot,
+		MaxFileSize: DefaultMaxFileSize,
+	}
+}
+
+// FileContent reads specified file content from the root directory.
+// Path argument is the root-related slash separated file path.
+func (lf LocalFetcher) FileContent(ctx context.Context, name string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	full, err := lf.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(full)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load '%s' file from local directory: %w", name, err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("parameter is a directory or not a valid file")
+	}
+	if lf.MaxFileSize > 0 && info.Size() > lf.MaxFileSize {
+		return nil, &FileTooLargeError{Path: name, Size: info.Size(), Limit: lf.MaxFileSize}
+	}
+
+	b, err := ioutil.ReadFile(full)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load '%s' file from local directory: %w", name, err)
+	}
+	return b, nil
+}
+
+// ListFiles returns sorted root-related paths of the files matching the pattern.
+//
+// Symbolic links to directories are never walked, symbolic links to files are listed according to the policy.
+// Version control directories (e.g. '.git') and directories which files can't match the pattern are skipped.
+func (lf LocalFetcher) ListFiles(ctx context.Context, pattern string) ([]string, error) {
+	res := []string{}
+	err := filepath.Walk(lf.Root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(lf.Root, file)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if info.IsDir() {
+			if name != "." && (isVCSDir(info.Name()) || !matchDirPrefix(pattern, name)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !MatchPattern(pattern, name) {
+			return nil
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			full, err := lf.resolve(name)
+			if err != nil {
+				return nil
+			}
+			if target, err := os.Stat(full); err != nil || !target.Mode().IsRegular() {
+				return nil
+			}
+		} else if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		res = append(res, name)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list local directory files: %w", err)
+	}
+
+	sort.Strings(res)
+	return res, nil
+}
+
+// isVCSDir - helper to check whether the directory name is a version control directory.
+func isVCSDir(name string) bool {
+	for _, vcs := range vcsDirs {
+		if name == vcs {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the file system path of the root-related file, path traversal and symbolic links policy are checked.
+func (lf LocalFetcher) resolve(name string) (string, error) {
+	clean := path.Clean(filepath.ToSlash(name))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", ErrPathOutsideRoot
+	}
+	full := filepath.Join(lf.Root, filepath.FromSlash(clean))
+
+	root, err := filepath.EvalSymlinks(lf.Root)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve local directory: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(full)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrFileNotFound
+		}
+		return "", fmt.Errorf("unable to load '%s' file from local directory: %w", name, err)
+	}
+
+	// Resolved path differs only if any of the path elements is a symbolic link
+	if resolved == filepath.Join(root, filepath.FromSlash(clean)) {
+		return full, nil
+	}
+	switch lf.Symlinks {
+	case SymlinksFollow:
+		return resolved, nil
+	case SymlinksWithinRoot:
+		rel, err := filepath.Rel(root, resolved)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("%w: '%s' points outside of the root directory", ErrSymlinkNotAllowed, name)
+		}
+		return resolved, nil
+	default:
+		return "", fmt.Errorf("%w: '%s'", ErrSymlinkNotAllowed, name)
+	}
+}
@@ -0,0 +1,135 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// localTree creates temporary directory with the files ('path:content' map).
+func localTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "dephub-local")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	for name, content := range files {
+		full := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("unable to create directory: %v", err)
+		}
+		if err := ioutil.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatalf("unable to write file: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestLocalFetcher_FileContent(t *testing.T) {
+	root := localTree(t, map[string]string{
+		"composer.json":             `{"require": {}}`,
+		"services/api/requirements": "Django==3.2.9",
+		"large.txt":                 "0123456789",
+	})
+	outside := localTree(t, map[string]string{"secret.txt": "secret"})
+	if err := os.Symlink(filepath.Join(root, "composer.json"), filepath.Join(root, "link.json")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(root, "secret.txt")); err != nil {
+		t.Fatalf("unable to create symbolic link: %v", err)
+	}
+
+	fetcher := NewLocalFetcher(root).(*LocalFetcher)
+	content, err := fetcher.FileContent(context.Background(), "services/api/requirements")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "Django==3.2.9" {
+		t.Errorf("unexpected file content: %q", content)
+	}
+
+	cases := map[string]error{
+		"missing.txt":            ErrFileNotFound,
+		"../secret.txt":          ErrPathOutsideRoot,
+		"services/../../x":       ErrPathOutsideRoot,
+		"/etc/passwd":            ErrPathOutsideRoot,
+		"secret.txt":             ErrSymlinkNotAllowed,
+		"services/api/../../..":  ErrPathOutsideRoot,
+		"services/api/none.json": ErrFileNotFound,
+	}
+	for name, expErr := range cases {
+		content, err := fetcher.FileContent(context.Background(), name)
+		if !errors.Is(err, expErr) {
+			t.Errorf("expected %q error on %q file, got: %v", expErr, name, err)
+		}
+		if content != nil {
+			t.Errorf("expected nil content on %q file, got: %q", name, content)
+		}
+	}
+
+	// Symbolic links policy
+	if _, err := fetcher.FileContent(context.Background(), "link.json"); err != nil {
+		t.Errorf("unexpected error on symbolic link within root: %v", err)
+	}
+	fetcher.Symlinks = SymlinksDeny
+	if _, err := fetcher.FileContent(context.Background(), "link.json"); !errors.Is(err, ErrSymlinkNotAllowed) {
+		t.Errorf("expected symbolic link error, got: %v", err)
+	}
+	fetcher.Symlinks = SymlinksFollow
+	if content, err := fetcher.FileContent(context.Background(), "secret.txt"); err != nil || string(content) != "secret" {
+		t.Errorf("unexpected result on followed symbolic link: %q, %v", content, err)
+	}
+
+	// Size limit
+	fetcher.MaxFileSize = 5
+	if _, err := fetcher.FileContent(context.Background(), "large.txt"); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("expected file too large error, got: %v", err)
+	}
+}
+
+func TestLocalFetcher_ListFiles(t *testing.T) {
+	root := localTree(t, map[string]string{
+		"composer.json":                  "{}",
+		"services/api/composer.json":     "{}",
+		"services/billing/composer.json": "{}",
+		"services/billing/README.md":     "",
+		".git/composer.json":             "{}",
+		"services/.git/composer.json":    "{}",
+	})
+	outside := localTree(t, map[string]string{"composer.json": "{}"})
+	if err := os.Symlink(filepath.Join(outside, "composer.json"), filepath.Join(root, "services", "composer.json")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+
+	fetcher := NewLocalFetcher(root).(*LocalFetcher)
+	files, err := fetcher.ListFiles(context.Background(), "**/composer.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"composer.json", "services/api/composer.json", "services/billing/composer.json"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("unexpected listed files: %+v", files)
+	}
+
+	fetcher.Symlinks = SymlinksFollow
+	files, err = fetcher.ListFiles(context.Background(), "services/*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"services/composer.json"}) {
+		t.Errorf("unexpected listed files with followed symbolic links: %+v", files)
+	}
+
+	_, err = LocalFetcher{Root: filepath.Join(root, "missing")}.ListFiles(context.Background(), "**")
+	if err == nil {
+		t.Errorf("expected error on missing root directory, got none")
+	}
+}
@@ -0,0 +1,260 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+// NewCargoParser constructs Cargo files parser.
+// If 'filename' parameter is an empty string - 'Cargo.toml' will be used instead.
+//
+// Cargo.lock is expected to be stored next to the manifest file (in the workspace root).
+func NewCargoParser(fetcher fetchers.FileFetcher, filename string) DependencyParser {
+	if filename == "" {
+		return &CargoParser{fetcher: fetcher, SourceName: "Cargo.toml"}
+	}
+	return &CargoParser{fetcher: fetcher, SourceName: filename}
+}
+
+// CargoParser represents concrete Cargo parser implementation.
+type CargoParser struct {
+	fetcher fetchers.FileFetcher
+	// SourceName is the manifest filename (e.g. 'Cargo.toml')
+	SourceName string
+}
+
+// CargoToml represents Cargo manifest file (Cargo.toml).
+//
+// Dependencies are kept raw, because every dependency can be either a version
+// string (e.g. 'serde = "1.0"') or a table (e.g. 'serde = { version = "1.0" }').
+type CargoToml struct {
+	Dependencies      map[string]interface{} `toml:"dependencies"`
+	BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+	DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+	Target            map[string]struct {
+		Dependencies      map[string]interface{} `toml:"dependencies"`
+		BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+		DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+	} `toml:"target"`
+	Workspace *struct {
+		Members      []string               `toml:"members"`
+		Exclude      []string               `toml:"exclude"`
+		Dependencies map[string]interface{} `toml:"dependencies"`
+	} `toml:"workspace"`
+}
+
+// CargoLock represents Cargo lock file (Cargo.lock).
+type CargoLock struct {
+	Package []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		Source  string `toml:"source"`
+	} `toml:"package"`
+}
+
+// cargoDependency represents one parsed dependency declaration.
+type cargoDependency struct {
+	key       string // dependency table key (differs from the name for renamed dependencies)
+	name      string
+	version   string
+	workspace bool // inherited from '[workspace.dependencies]'
+	local     bool // path or git dependency, not served by a registry
+}
+
+// Constraints method returns Cargo.toml constraints.
+//
+// Constraints are collected from '[dependencies]', '[build-dependencies]' and
+// '[target.*.dependencies]' tables of the manifest and of every workspace member.
+// Path and git dependencies are skipped, because they are not served by a registry.
+func (c CargoParser) Constraints(ctx context.Context) ([]Constraint, error) {
+	manifest, err := c.manifest(ctx, c.SourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var wsDeps map[string]cargoDependency
+	manifests := []*CargoToml{manifest}
+	if manifest.Workspace != nil {
+		wsDeps = cargoDependencies(manifest.Workspace.Dependencies)
+
+		members, err := c.members(ctx, manifest)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			memberManifest, err := c.manifest(ctx, member)
+			if err != nil {
+				if err == ErrFileNotFound {
+					continue
+				}
+				return nil, err
+			}
+			manifests = append(manifests, memberManifest)
+		}
+	}
+
+	res := []Constraint{}
+	seen := map[Constraint]struct{}{}
+	for _, m := range manifests {
+		for _, dep := range m.registryDependencies() {
+			if dep.workspace {
+				wsDep, ok := wsDeps[dep.key]
+				if !ok {
+					continue
+				}
+				dep = wsDep
+			}
+			if dep.local || dep.version == "" {
+				continue
+			}
+
+			cns := Constraint{Name: dep.name, Version: dep.version}
+			if _, ok := seen[cns]; ok {
+				continue
+			}
+			seen[cns] = struct{}{}
+			res = append(res, cns)
+		}
+	}
+
+	return res, nil
+}
+
+// Requirements method returns locked packages versions from Cargo.lock.
+//
+// Workspace members and git dependencies are skipped, only registry packages are returned.
+func (c CargoParser) Requirements(ctx context.Context) ([]Requirement, error) {
+	constraints, err := c.Constraints(ctx)
+	if err != nil && err != ErrFileNotFound {
+		return nil, err
+	}
+
+	basePkgs := map[string]struct{}{}
+	for _, cn := range constraints {
+		basePkgs[cn.Name] = struct{}{}
+	}
+
+	b, err := c.fetcher.FileContent(ctx, path.Join(path.Dir(c.SourceName), "Cargo.lock"))
+	if err != nil {
+		if err == fetchers.ErrFileNotFound {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("unable to fetch cargo dependencies from the source: %w", err)
+	}
+
+	var lock CargoLock
+	if err = toml.Unmarshal(b, &lock); err != nil {
+		return nil, fmt.Errorf("unable to parse cargo lock file content: %w", err)
+	}
+
+	res := make([]Requirement, 0, len(lock.Package))
+	for _, pkg := range lock.Package {
+		if !strings.HasPrefix(pkg.Source, "registry+") && !strings.HasPrefix(pkg.Source, "sparse+") {
+			continue
+		}
+		_, base := basePkgs[pkg.Name]
+		res = append(res, Requirement{Name: pkg.Name, Version: pkg.Version, Base: base})
+	}
+
+	return res, nil
+}
+
+// members returns workspace members manifests paths.
+//
+// Glob members (e.g. 'crates/*') are resolved only if the fetcher is able to list files,
+// otherwise they are skipped. Excluded members are skipped as well.
+func (c CargoParser) members(ctx context.Context, manifest *CargoToml) ([]string, error) {
+	root := path.Dir(c.SourceName)
+	excluded := map[string]struct{}{}
+	for _, ex := range manifest.Workspace.Exclude {
+		excluded[path.Join(root, ex, "Cargo.toml")] = struct{}{}
+	}
+
+	var res []string
+	for _, member := range manifest.Workspace.Members {
+		pattern := path.Join(root, member, "Cargo.toml")
+		if !fetchers.IsPattern(member) {
+			res = append(res, pattern)
+			continue
+		}
+		lister, ok := c.fetcher.(fetchers.FileLister)
+		if !ok {
+			continue
+		}
+		files, err := lister.ListFiles(ctx, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list cargo workspace members: %w", err)
+		}
+		for _, file := range files {
+			if _, ok := excluded[file]; !ok {
+				res = append(res, file)
+			}
+		}
+	}
+	return res, nil
+}
+
+// manifest fetches and decodes Cargo manifest file.
+func (c CargoParser) manifest(ctx context.Context, filename string) (*CargoToml, error) {
+	b, err := c.fetcher.FileContent(ctx, filename)
+	if err != nil {
+		if err == fetchers.ErrFileNotFound {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("unable to fetch cargo dependencies from the source: %w", err)
+	}
+
+	var manifest CargoToml
+	if err = toml.Unmarshal(b, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse cargo manifest file content: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// registryDependencies returns all non-dev dependencies of the manifest, including platform specific ones.
+func (ct CargoToml) registryDependencies() []cargoDependency {
+	tables := []map[string]interface{}{ct.Dependencies, ct.BuildDependencies}
+	for _, target := range ct.Target {
+		tables = append(tables, target.Dependencies, target.BuildDependencies)
+	}
+
+	var res []cargoDependency
+	for _, table := range tables {
+		for _, dep := range cargoDependencies(table) {
+			res = append(res, dep)
+		}
+	}
+	return res
+}
+
+// cargoDependencies converts raw dependencies table into the dependencies map (keyed by the table key).
+func cargoDependencies(table map[string]interface{}) map[string]cargoDependency {
+	res := make(map[string]cargoDependency, len(table))
+	for key, raw := range table {
+		dep := cargoDependency{key: key, name: key}
+		switch val := raw.(type) {
+		case string:
+			dep.version = val
+		case map[string]interface{}:
+			// 'package' key is used to rename dependencies: 'alias = { package = "real-name" }'
+			if pkg, ok := val["package"].(string); ok && pkg != "" {
+				dep.name = pkg
+			}
+			dep.version, _ = val["version"].(string)
+			dep.workspace, _ = val["workspace"].(bool)
+			_, isPath := val["path"]
+			_, isGit := val["git"]
+			dep.local = isPath || isGit
+		default:
+			continue
+		}
+		res[key] = dep
+	}
+	return res
+}
@@ -0,0 +1,223 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+func TestCargoConstraintsMethod(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"Cargo.toml":             []byte(cargoTomlFixture),
+		"crates/core/Cargo.toml": []byte(cargoMemberTomlFixture),
+		"plugins/cli/Cargo.toml": []byte(`
+			[dependencies]
+			clap = "3.0.0-beta.5"
+		`),
+		"plugins/legacy/Cargo.toml": []byte(`
+			[dependencies]
+			getopts = "0.2"
+		`),
+	}}
+	parser := NewCargoParser(bf, "")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on cargo constraints call : %v", err)
+	}
+
+	expectedConstraints := []Constraint{
+		{Name: "serde", Version: "1.0"},
+		{Name: "tokio", Version: "1.12"},
+		{Name: "cc", Version: "~1.0.70"},
+		{Name: "winapi", Version: "0.3.9"},
+		{Name: "nix", Version: "^0.23"},
+		{Name: "log", Version: "0.4.14"},
+		{Name: "rand_core", Version: ">=0.6, <0.7"},
+		{Name: "clap", Version: "3.0.0-beta.5"},
+	}
+
+	// Sort before DeepEqual test
+	sort.Slice(cns, func(i, j int) bool {
+		return cns[i].Name > cns[j].Name
+	})
+	sort.Slice(expectedConstraints, func(i, j int) bool {
+		return expectedConstraints[i].Name > expectedConstraints[j].Name
+	})
+
+	if !reflect.DeepEqual(cns, expectedConstraints) {
+		t.Errorf("unexpected cargo constraints, got: '%+v", cns)
+	}
+}
+
+func TestCargoConstraintsMethod_Errors(t *testing.T) {
+	// Table test cases
+	cases := []struct {
+		Name  string
+		Files map[string][]byte
+		Err   string
+	}{
+		{"missing", map[string][]byte{"blablabla": []byte("")}, ErrFileNotFound.Error()},
+		{"broken", map[string][]byte{"Cargo.toml": []byte("[dependencies")}, "unable to parse cargo manifest file content"},
+	}
+
+	for _, v := range cases {
+		t.Run(v.Name, func(t *testing.T) {
+			bf := fetchers.ByteMapFetcher{Files: v.Files}
+			parser := NewCargoParser(bf, "")
+
+			cns, err := parser.Constraints(context.Background())
+			if err == nil || !strings.Contains(err.Error(), v.Err) {
+				t.Errorf("expected error %q, got: %v", v.Err, err)
+			}
+			if cns != nil {
+				t.Errorf("expected nil constraints, got: %+v", cns)
+			}
+		})
+	}
+}
+
+func TestCargoRequirementsMethod(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"rust/Cargo.toml": []byte(`
+			[package]
+			name = "app"
+			version = "0.1.0"
+
+			[dependencies]
+			serde = "1.0"
+		`),
+		"rust/Cargo.lock": []byte(cargoLockFixture),
+	}}
+	parser := NewCargoParser(bf, "rust/Cargo.toml")
+
+	reqs, err := parser.Requirements(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on cargo requirements call : %v", err)
+	}
+
+	expectedRequirements := []Requirement{
+		{Name: "serde", Version: "1.0.130", Base: true},
+		{Name: "serde_derive", Version: "1.0.130"},
+	}
+
+	// Sort before DeepEqual test
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].Name > reqs[j].Name
+	})
+	sort.Slice(expectedRequirements, func(i, j int) bool {
+		return expectedRequirements[i].Name > expectedRequirements[j].Name
+	})
+
+	if !reflect.DeepEqual(reqs, expectedRequirements) {
+		t.Errorf("unexpected cargo requirements, got: '%+v", reqs)
+	}
+}
+
+func TestCargoRequirementsMethod_Errors(t *testing.T) {
+	// Table test cases
+	cases := []struct {
+		Name  string
+		Files map[string][]byte
+		Err   string
+	}{
+		{"missing", map[string][]byte{"Cargo.toml": []byte("")}, ErrFileNotFound.Error()},
+		{"broken", map[string][]byte{"Cargo.lock": []byte("[[package]")}, "unable to parse cargo lock file content"},
+	}
+
+	for _, v := range cases {
+		t.Run(v.Name, func(t *testing.T) {
+			bf := fetchers.ByteMapFetcher{Files: v.Files}
+			parser := NewCargoParser(bf, "")
+
+			reqs, err := parser.Requirements(context.Background())
+			if err == nil || !strings.Contains(err.Error(), v.Err) {
+				t.Errorf("expected error %q, got: %v", v.Err, err)
+			}
+			if reqs != nil {
+				t.Errorf("expected nil requirements, got: %+v", reqs)
+			}
+		})
+	}
+}
+
+var cargoTomlFixture = `
+[package]
+name = "app"
+version = "0.1.0"
+
+[workspace]
+members = ["crates/core", "crates/missing", "plugins/*"]
+exclude = ["plugins/legacy"]
+
+[workspace.dependencies]
+log = "0.4.14"
+core = { path = "crates/core" }
+
+[dependencies]
+serde = "1.0"
+tokio = { version = "1.12", features = ["full"] }
+core = { workspace = true }
+local = { path = "../local" }
+fork = { git = "https://github.com/example/fork" }
+
+[dev-dependencies]
+criterion = "0.3"
+
+[build-dependencies]
+cc = "~1.0.70"
+
+[target.'cfg(windows)'.dependencies]
+winapi = { version = "0.3.9", features = ["winuser"] }
+
+[target.'cfg(unix)'.dependencies]
+nix = "^0.23"
+`
+
+var cargoMemberTomlFixture = `
+[package]
+name = "core"
+version = "0.1.0"
+
+[dependencies]
+serde = "1.0"
+log = { workspace = true }
+rng = { package = "rand_core", version = ">=0.6, <0.7" }
+`
+
+var cargoLockFixture = `
+# This file is automatically @generated by Cargo.
+# It is not intended for manual editing.
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "fork"
+version = "0.1.0"
+source = "git+https://github.com/example/fork#4f3c4a3e"
+
+[[package]]
+name = "serde"
+version = "1.0.130"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "f12d06de37cf59146fbdecab66aa99f9fe4f78722e3607577a5375d66bd0c913"
+dependencies = [
+ "serde_derive",
+]
+
+[[package]]
+name = "serde_derive"
+version = "1.0.130"
+source = "sparse+https://index.crates.io/"
+checksum = "d7bc1a1ab1961464eae040d96713baa5a724a8152c1222492465b54322ec508b"
+`
@@ -4,18 +4,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 
 	"github.com/dephub/dephub-core/providers/fetchers"
 )
 
-// NewComposerParser constructs Composer files parser.
+// NewComposerParser constructs Composer files parser for the root 'composer.json'.
 func NewComposerParser(fetcher fetchers.FileFetcher) DependencyParser {
-	return &ComposerParser{fetcher: fetcher}
+	return NewComposerFileParser(fetcher, "")
+}
+
+// NewComposerFileParser constructs Composer files parser.
+// If 'filename' parameter is an empty string - 'composer.json' will be used instead.
+//
+// composer.lock is expected to be stored next to the manifest file.
+func NewComposerFileParser(fetcher fetchers.FileFetcher, filename string) DependencyParser {
+	if filename == "" {
+		return &ComposerParser{fetcher: fetcher, SourceName: "composer.json"}
+	}
+	return &ComposerParser{fetcher: fetcher, SourceName: filename}
 }
 
 // ComposerParser represents concrete Composer parser implementation.
 type ComposerParser struct {
 	fetcher fetchers.FileFetcher
+	// SourceName is the manifest filename (e.g. 'composer.json')
+	SourceName string
 }
 
 // ComposerLock represents Composer lock file (composer.lock).
@@ -32,7 +46,7 @@ type ComposerJson struct {
 
 // Constraints method returns composer.json constraints.
 func (c ComposerParser) Constraints(ctx context.Context) ([]Constraint, error) {
-	b, err := c.fetcher.FileContent(ctx, "composer.json")
+	b, err := c.fetcher.FileContent(ctx, c.SourceName)
 	if err != nil {
 		if err == fetchers.ErrFileNotFound {
 			return nil, ErrFileNotFound
@@ -70,7 +84,7 @@ func (c ComposerParser) Requirements(ctx context.Context) ([]Requirement, error)
 		basePkgs[cn.Name] = struct{}{}
 	}
 
-	b, err := c.fetcher.FileContent(ctx, "composer.lock")
+	b, err := c.fetcher.FileContent(ctx, path.Join(path.Dir(c.SourceName), "composer.lock"))
 	if err != nil {
 		if err == fetchers.ErrFileNotFound {
 			return nil, ErrFileNotFound
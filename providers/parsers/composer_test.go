@@ -153,3 +153,35 @@ func TestComposerRequirementsMethod_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestComposerFileParser(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"services/api/composer.json": []byte(`{"require": {"monolog/monolog": "^2.0"}}`),
+		"services/api/composer.lock": []byte(`{"packages": [{"name": "monolog/monolog", "version": "2.3.5"}, {"name": "psr/log", "version": "1.1.4"}]}`),
+	}}
+	parser := NewComposerFileParser(bf, "services/api/composer.json")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on composer constraints call : %v", err)
+	}
+	if !reflect.DeepEqual(cns, []Constraint{{Name: "monolog/monolog", Version: "^2.0"}}) {
+		t.Errorf("unexpected composer constraints, got: '%+v", cns)
+	}
+
+	reqs, err := parser.Requirements(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on composer requirements call : %v", err)
+	}
+	expectedRequirements := []Requirement{
+		{Name: "monolog/monolog", Version: "2.3.5", Base: true},
+		{Name: "psr/log", Version: "1.1.4"},
+	}
+	if !reflect.DeepEqual(reqs, expectedRequirements) {
+		t.Errorf("unexpected composer requirements, got: '%+v", reqs)
+	}
+
+	if p := NewComposerParser(bf).(*ComposerParser); p.SourceName != "composer.json" {
+		t.Errorf("unexpected default composer source name %q", p.SourceName)
+	}
+}
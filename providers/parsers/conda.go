@@ -0,0 +1,196 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+	"gopkg.in/yaml.v3"
+)
+
+// condaSpecSpaces removes spaces around the match spec conjunctions.
+var condaSpecSpaces = strings.NewReplacer(", ", ",", " ,", ",", " |", "|", "| ", "|")
+
+// condaDefaultChannel is used for the dependencies when environment has no channels.
+const condaDefaultChannel = "defaults"
+
+// NewCondaParser constructs conda files parser.
+// If 'filename' parameter is an empty string - 'environment.yml' will be used instead.
+func NewCondaParser(fetcher fetchers.FileFetcher, filename string) DependencyParser {
+	if filename == "" {
+		return &CondaParser{fetcher: fetcher, SourceName: "environment.yml"}
+	}
+	return &CondaParser{fetcher: fetcher, SourceName: filename}
+}
+
+// CondaParser represents concrete conda parser implementation.
+type CondaParser struct {
+	fetcher fetchers.FileFetcher
+	// SourceName is the environment filename (e.g. 'environment.yml')
+	SourceName string
+}
+
+// CondaEnvironment represents conda environment file (environment.yml).
+//
+// Dependencies are kept raw, because every dependency can be either a match spec string
+// (e.g. 'numpy=1.21') or a nested package manager list (e.g. 'pip: [requests==2.26.0]').
+type CondaEnvironment struct {
+	Name         string        `yaml:"name"`
+	Channels     []string      `yaml:"channels"`
+	Dependencies []interface{} `yaml:"dependencies"`
+}
+
+// Requirements method always returns nil values, because environment file doesnt contain locked deps lists.
+func (c CondaParser) Requirements(ctx context.Context) ([]Requirement, error) {
+	return nil, nil
+}
+
+// Constraints method returns conda dependencies constraints.
+//
+// Names are formatted as 'channel::name', where the channel is taken from the match spec
+// (e.g. 'conda-forge::numpy') or is the first environment channel. Build strings are dropped
+// and nested pip dependencies are skipped (use PipParser with the environment file to get them).
+func (c CondaParser) Constraints(ctx context.Context) ([]Constraint, error) {
+	b, err := c.fetcher.FileContent(ctx, c.SourceName)
+	if err != nil {
+		if err == fetchers.ErrFileNotFound {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("unable to fetch conda dependencies from the source: %w", err)
+	}
+
+	env, err := parseCondaEnvironment(b)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := condaDefaultChannel
+	for _, ch := range env.Channels {
+		if ch != "nodefaults" {
+			channel = ch
+			break
+		}
+	}
+
+	res := []Constraint{}
+	seen := map[Constraint]struct{}{}
+	for _, dep := range env.Dependencies {
+		spec, ok := dep.(string)
+		if !ok {
+			continue
+		}
+		cns, ok := parseCondaSpec(spec, channel)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[cns]; ok {
+			continue
+		}
+		seen[cns] = struct{}{}
+		res = append(res, cns)
+	}
+
+	return res, nil
+}
+
+// HasPipDependencies reports whether the environment file has nested pip dependencies ('pip:' section),
+// such file is the pip manifest as well (see PipParser).
+func (c CondaParser) HasPipDependencies(ctx context.Context) (bool, error) {
+	b, err := c.fetcher.FileContent(ctx, c.SourceName)
+	if err != nil {
+		if err == fetchers.ErrFileNotFound {
+			return false, ErrFileNotFound
+		}
+		return false, fmt.Errorf("unable to fetch conda dependencies from the source: %w", err)
+	}
+
+	env, err := parseCondaEnvironment(b)
+	if err != nil {
+		return false, err
+	}
+	return len(env.PipDependencies()) != 0, nil
+}
+
+// PipDependencies returns nested pip dependencies list (in the requirements.txt format).
+func (ce CondaEnvironment) PipDependencies() []string {
+	var res []string
+	for _, dep := range ce.Dependencies {
+		nested, ok := dep.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pipDeps, ok := nested["pip"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, pipDep := range pipDeps {
+			if line, ok := pipDep.(string); ok {
+				res = append(res, line)
+			}
+		}
+	}
+	return res
+}
+
+// parseCondaEnvironment decodes environment file content.
+func parseCondaEnvironment(content []byte) (*CondaEnvironment, error) {
+	var env CondaEnvironment
+	if err := yaml.Unmarshal(content, &env); err != nil {
+		return nil, fmt.Errorf("unable to parse conda environment file content: %w", err)
+	}
+	return &env, nil
+}
+
+// IsCondaEnvironment reports whether the file is a conda environment file (by it's name).
+func IsCondaEnvironment(filename string) bool {
+	switch path.Base(filename) {
+	case "environment.yml", "environment.yaml":
+		return true
+	}
+	return false
+}
+
+// parseCondaSpec converts conda match spec (e.g. 'conda-forge::numpy>=1.20,<1.22' or 'numpy=1.21=py39h_0')
+// into the constraint, channel is used if the spec has no explicit one.
+func parseCondaSpec(spec, channel string) (Constraint, bool) {
+	spec = strings.TrimSpace(strings.Split(spec, "#")[0])
+	if idx := strings.Index(spec, "::"); idx != -1 {
+		channel = spec[:idx]
+		// Channel can contain the subdir (e.g. 'conda-forge/linux-64::numpy')
+		if sub := strings.Index(channel, "/"); sub != -1 && !strings.Contains(channel, "://") {
+			channel = channel[:sub]
+		}
+		spec = spec[idx+2:]
+	}
+
+	end := strings.IndexAny(spec, " =<>!~[")
+	if end == -1 {
+		end = len(spec)
+	}
+	name, rest := spec[:end], strings.TrimSpace(spec[end:])
+	if name == "" || strings.HasPrefix(rest, "[") {
+		return Constraint{}, false
+	}
+
+	// Conjunctions can be spaced (e.g. '>=1.20, <1.22'), the build string is separated with a space
+	rest = condaSpecSpaces.Replace(rest)
+	cns := Constraint{Name: channel + "::" + name, Version: "*"}
+	if fields := strings.Fields(rest); len(fields) != 0 {
+		version := fields[0]
+		switch true {
+		case strings.HasPrefix(version, "=="):
+			// 'numpy==1.21.2=py39h_0' is an exact version with the build string
+			version = "==" + strings.Split(version[2:], "=")[0]
+		case strings.HasPrefix(version, "="):
+			// 'numpy=1.21=py39h_0' is a fuzzy version with the build string
+			version = "=" + strings.Split(version[1:], "=")[0]
+		}
+		if version != "=" && version != "==" {
+			cns.Version = version
+		}
+	}
+
+	return cns, true
+}
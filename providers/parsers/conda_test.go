@@ -0,0 +1,147 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+func TestCondaConstraintsMethod(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"environment.yml": []byte(condaEnvironmentFixture),
+	}}
+	parser := NewCondaParser(bf, "")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on conda constraints call : %v", err)
+	}
+
+	expectedConstraints := []Constraint{
+		{Name: "conda-forge::python", Version: "=3.9"},
+		{Name: "conda-forge::numpy", Version: ">=1.20,<1.22"},
+		{Name: "conda-forge::pandas", Version: "=1.3.4"},
+		{Name: "conda-forge::scipy", Version: "1.7.*"},
+		{Name: "conda-forge::matplotlib", Version: "==3.4.3"},
+		{Name: "conda-forge::pip", Version: "*"},
+		{Name: "pytorch::pytorch", Version: "=1.10"},
+		{Name: "nvidia::cudatoolkit", Version: "11.3.1"},
+	}
+
+	// Sort before DeepEqual test
+	sort.Slice(cns, func(i, j int) bool {
+		return cns[i].Name > cns[j].Name
+	})
+	sort.Slice(expectedConstraints, func(i, j int) bool {
+		return expectedConstraints[i].Name > expectedConstraints[j].Name
+	})
+
+	if !reflect.DeepEqual(cns, expectedConstraints) {
+		t.Errorf("unexpected conda constraints, got: '%+v", cns)
+	}
+}
+
+func TestCondaConstraintsMethod_DefaultChannel(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"env/environment.yaml": []byte("dependencies:\n  - requests\n"),
+	}}
+	parser := NewCondaParser(bf, "env/environment.yaml")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on conda constraints call : %v", err)
+	}
+	if !reflect.DeepEqual(cns, []Constraint{{Name: "defaults::requests", Version: "*"}}) {
+		t.Errorf("unexpected conda constraints, got: '%+v", cns)
+	}
+}
+
+func TestCondaConstraintsMethod_Errors(t *testing.T) {
+	// Table test cases
+	cases := []struct {
+		Name  string
+		Files map[string][]byte
+		Err   string
+	}{
+		{"missing", map[string][]byte{"blablabla": []byte("")}, ErrFileNotFound.Error()},
+		{"broken", map[string][]byte{"environment.yml": []byte("dependencies: [")}, "unable to parse conda environment file content"},
+	}
+
+	for _, v := range cases {
+		t.Run(v.Name, func(t *testing.T) {
+			bf := fetchers.ByteMapFetcher{Files: v.Files}
+			parser := NewCondaParser(bf, "")
+
+			cns, err := parser.Constraints(context.Background())
+			if err == nil || !strings.Contains(err.Error(), v.Err) {
+				t.Errorf("expected error %q, got: %v", v.Err, err)
+			}
+			if cns != nil {
+				t.Errorf("expected nil constraints, got: %+v", cns)
+			}
+		})
+	}
+}
+
+func TestCondaRequirementsMethod(t *testing.T) {
+	parser := NewCondaParser(fetchers.ByteMapFetcher{}, "")
+
+	reqs, err := parser.Requirements(context.Background())
+	if reqs != nil || err != nil {
+		t.Errorf("expected nil requirements and error, got: %+v, %v", reqs, err)
+	}
+}
+
+func TestPipParserConstraintsMethod_CondaEnvironment(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"environment.yml": []byte(condaEnvironmentFixture),
+	}}
+	parser := NewPipParser(bf, "environment.yml")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on pip constraints call : %v", err)
+	}
+
+	expectedConstraints := []Constraint{
+		{Name: "requests", Version: "==2.26.0"},
+		{Name: "black", Version: "*"},
+	}
+
+	// Sort before DeepEqual test
+	sort.Slice(cns, func(i, j int) bool {
+		return cns[i].Name > cns[j].Name
+	})
+	sort.Slice(expectedConstraints, func(i, j int) bool {
+		return expectedConstraints[i].Name > expectedConstraints[j].Name
+	})
+
+	if !reflect.DeepEqual(cns, expectedConstraints) {
+		t.Errorf("unexpected pip constraints, got: '%+v", cns)
+	}
+}
+
+var condaEnvironmentFixture = `
+name: analytics
+channels:
+  - conda-forge
+  - defaults
+dependencies:
+  - python=3.9
+  - numpy >=1.20, <1.22
+  - pandas=1.3.4=py39hde0f152_0
+  - scipy 1.7.*
+  - matplotlib==3.4.3
+  - pytorch::pytorch=1.10
+  - nvidia/linux-64::cudatoolkit 11.3.1 h2bc3f7f_2
+  - numpy[version='>=1.20']
+  - pip
+  - pip:
+    - requests==2.26.0
+    - black
+    - -e ./local
+`
@@ -0,0 +1,152 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+// NewGradleParser constructs Gradle version catalog parser.
+// If 'filename' parameter is an empty string - 'gradle/libs.versions.toml' will be used instead.
+func NewGradleParser(fetcher fetchers.FileFetcher, filename string) DependencyParser {
+	if filename == "" {
+		return &GradleParser{fetcher: fetcher, SourceName: "gradle/libs.versions.toml"}
+	}
+	return &GradleParser{fetcher: fetcher, SourceName: filename}
+}
+
+// GradleParser represents concrete Gradle version catalog parser implementation.
+type GradleParser struct {
+	fetcher fetchers.FileFetcher
+	// SourceName is the version catalog filename (e.g. 'gradle/libs.versions.toml')
+	SourceName string
+}
+
+// GradleCatalog represents Gradle version catalog file (libs.versions.toml).
+//
+// Versions and libraries are kept raw, because every entry can be either a string
+// (e.g. 'guava = "com.google.guava:guava:31.0.1-jre"') or a table.
+type GradleCatalog struct {
+	Versions  map[string]interface{} `toml:"versions"`
+	Libraries map[string]interface{} `toml:"libraries"`
+}
+
+// Requirements method always returns nil values, because version catalog doesnt contain locked deps lists.
+func (c GradleParser) Requirements(ctx context.Context) ([]Requirement, error) {
+	return nil, nil
+}
+
+// Constraints method returns version catalog libraries constraints.
+//
+// Names are formatted as 'group:name' (same as maven), version references are resolved
+// using the '[versions]' table and Gradle ranges (e.g. '[1.0,2.0[') are converted to the maven syntax.
+// Libraries without version (e.g. managed by a platform) are skipped.
+func (c GradleParser) Constraints(ctx context.Context) ([]Constraint, error) {
+	b, err := c.fetcher.FileContent(ctx, c.SourceName)
+	if err != nil {
+		if err == fetchers.ErrFileNotFound {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("unable to fetch gradle dependencies from the source: %w", err)
+	}
+
+	var catalog GradleCatalog
+	if _, err = toml.Decode(string(b), &catalog); err != nil {
+		return nil, fmt.Errorf("unable to parse gradle version catalog file content: %w", err)
+	}
+
+	res := []Constraint{}
+	seen := map[Constraint]struct{}{}
+	for _, raw := range catalog.Libraries {
+		name, version := gradleLibrary(raw, catalog.Versions)
+		if name == "" || version == "" {
+			continue
+		}
+
+		cns := Constraint{Name: name, Version: gradleRange(version)}
+		if _, ok := seen[cns]; ok {
+			continue
+		}
+		seen[cns] = struct{}{}
+		res = append(res, cns)
+	}
+
+	return res, nil
+}
+
+// gradleLibrary returns library 'group:name' and it's version declaration.
+func gradleLibrary(raw interface{}, versions map[string]interface{}) (string, string) {
+	switch lib := raw.(type) {
+	case string:
+		// 'group:name:version' notation
+		parts := strings.Split(lib, ":")
+		if len(parts) != 3 {
+			return "", ""
+		}
+		return parts[0] + ":" + parts[1], parts[2]
+	case map[string]interface{}:
+		var name string
+		if module, ok := lib["module"].(string); ok {
+			name = module
+		} else {
+			group, _ := lib["group"].(string)
+			artifact, _ := lib["name"].(string)
+			if group == "" || artifact == "" {
+				return "", ""
+			}
+			name = group + ":" + artifact
+		}
+
+		// 'version.ref' dotted key is decoded as a nested table
+		switch ver := lib["version"].(type) {
+		case map[string]interface{}:
+			if ref, ok := ver["ref"].(string); ok {
+				return name, gradleVersion(versions[ref])
+			}
+			return name, gradleVersion(ver)
+		default:
+			return name, gradleVersion(ver)
+		}
+	}
+	return "", ""
+}
+
+// gradleVersion returns version declaration from a string or a rich version table.
+//
+// The strongest declaration of the rich version table is used ('strictly', then 'require', then 'prefer').
+func gradleVersion(raw interface{}) string {
+	switch ver := raw.(type) {
+	case string:
+		return strings.TrimSpace(ver)
+	case map[string]interface{}:
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if v, ok := ver[key].(string); ok && v != "" {
+				return strings.TrimSpace(v)
+			}
+		}
+	}
+	return ""
+}
+
+// gradleRange converts Gradle specific range syntax to the maven one.
+//
+// Gradle allows reversed brackets for exclusive bounds (e.g. ']1.0,2.0[' means '(1.0,2.0)').
+// Strict version short notation (e.g. '1.7!!1.7.25') is reduced to the strict part.
+func gradleRange(version string) string {
+	if idx := strings.Index(version, "!!"); idx >= 0 {
+		version = version[:idx]
+	}
+	if !strings.Contains(version, ",") || len(version) < 2 {
+		return version
+	}
+	if version[0] == ']' {
+		version = "(" + version[1:]
+	}
+	if version[len(version)-1] == '[' {
+		version = version[:len(version)-1] + ")"
+	}
+	return version
+}
@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+func TestGradleConstraintsMethod(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"gradle/libs.versions.toml": []byte(gradleCatalogFixture),
+	}}
+	parser := NewGradleParser(bf, "")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on gradle constraints call : %v", err)
+	}
+
+	expectedConstraints := []Constraint{
+		{Name: "com.google.guava:guava", Version: "31.0.1-jre"},
+		{Name: "org.slf4j:slf4j-api", Version: "[1.7,2.0)"},
+		{Name: "org.jetbrains.kotlinx:kotlinx-coroutines-core", Version: "1.5.2"},
+		{Name: "com.squareup.okhttp3:okhttp", Version: "4.9.2"},
+		{Name: "com.squareup.okhttp3:logging-interceptor", Version: "4.9.2"},
+		{Name: "commons-io:commons-io", Version: "(2.0,3.0)"},
+		{Name: "org.apache.commons:commons-lang3", Version: "3.12.0"},
+	}
+
+	// Sort before DeepEqual test
+	sort.Slice(cns, func(i, j int) bool {
+		return cns[i].Name > cns[j].Name
+	})
+	sort.Slice(expectedConstraints, func(i, j int) bool {
+		return expectedConstraints[i].Name > expectedConstraints[j].Name
+	})
+
+	if !reflect.DeepEqual(cns, expectedConstraints) {
+		t.Errorf("unexpected gradle constraints, got: '%+v", cns)
+	}
+}
+
+func TestGradleConstraintsMethod_Errors(t *testing.T) {
+	// Table test cases
+	cases := []struct {
+		Name  string
+		Files map[string][]byte
+		Err   string
+	}{
+		{"missing", map[string][]byte{"blablabla": []byte("")}, ErrFileNotFound.Error()},
+		{"broken", map[string][]byte{"gradle/libs.versions.toml": []byte("[libraries")}, "unable to parse gradle version catalog file content"},
+	}
+
+	for _, v := range cases {
+		t.Run(v.Name, func(t *testing.T) {
+			bf := fetchers.ByteMapFetcher{Files: v.Files}
+			parser := NewGradleParser(bf, "")
+
+			cns, err := parser.Constraints(context.Background())
+			if err == nil || !strings.Contains(err.Error(), v.Err) {
+				t.Errorf("expected error %q, got: %v", v.Err, err)
+			}
+			if cns != nil {
+				t.Errorf("expected nil constraints, got: %+v", cns)
+			}
+		})
+	}
+}
+
+func TestGradleRequirementsMethod(t *testing.T) {
+	parser := NewGradleParser(fetchers.ByteMapFetcher{}, "")
+
+	reqs, err := parser.Requirements(context.Background())
+	if reqs != nil || err != nil {
+		t.Errorf("expected nil requirements and error, got: %+v, %v", reqs, err)
+	}
+}
+
+var gradleCatalogFixture = `
+[versions]
+slf4j = "[1.7,2.0["
+coroutines = { strictly = "1.5.2" }
+okhttp = "4.9.2"
+
+[libraries]
+guava = "com.google.guava:guava:31.0.1-jre"
+slf4j-api = { module = "org.slf4j:slf4j-api", version.ref = "slf4j" }
+coroutines = { group = "org.jetbrains.kotlinx", name = "kotlinx-coroutines-core", version.ref = "coroutines" }
+okhttp = { module = "com.squareup.okhttp3:okhttp", version = { ref = "okhttp" } }
+okhttp-logging = { module = "com.squareup.okhttp3:logging-interceptor", version.ref = "okhttp" }
+commons-io = { module = "commons-io:commons-io", version = "]2.0,3.0[" }
+commons-lang = { module = "org.apache.commons:commons-lang3", version = { require = "3.12.0", prefer = "3.12.0" } }
+okhttp-bom = { module = "com.squareup.okhttp3:okhttp-bom" }
+unknown = { module = "org.unknown:unknown", version.ref = "missing" }
+
+[bundles]
+okhttp = ["okhttp", "okhttp-logging"]
+
+[plugins]
+kotlin = { id = "org.jetbrains.kotlin.jvm", version = "1.5.31" }
+`
@@ -0,0 +1,292 @@
+package parsers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+// mavenMaxParents limits parent POMs resolution depth (protects from cyclic parents).
+const mavenMaxParents = 16
+
+// mavenPropertyRgx matches property references (e.g. '${spring.version}').
+var mavenPropertyRgx = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// NewMavenParser constructs Maven files parser.
+// If 'filename' parameter is an empty string - 'pom.xml' will be used instead.
+func NewMavenParser(fetcher fetchers.FileFetcher, filename string) DependencyParser {
+	if filename == "" {
+		return &MavenParser{fetcher: fetcher, SourceName: "pom.xml"}
+	}
+	return &MavenParser{fetcher: fetcher, SourceName: filename}
+}
+
+// MavenParser represents concrete Maven parser implementation.
+type MavenParser struct {
+	fetcher fetchers.FileFetcher
+	// SourceName is the POM filename (e.g. 'pom.xml')
+	SourceName string
+}
+
+// MavenPom represents Maven project object model file (pom.xml).
+type MavenPom struct {
+	XMLName              xml.Name          `xml:"project"`
+	GroupID              string            `xml:"groupId"`
+	ArtifactID           string            `xml:"artifactId"`
+	Version              string            `xml:"version"`
+	Parent               *MavenParent      `xml:"parent"`
+	Properties           MavenProperties   `xml:"properties"`
+	Dependencies         []MavenDependency `xml:"dependencies>dependency"`
+	DependencyManagement []MavenDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+// MavenParent represents parent POM reference.
+type MavenParent struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	// RelativePath is nil when it is not set (defaults to '../pom.xml')
+	// and is empty when the parent must be looked up in the repositories.
+	RelativePath *string `xml:"relativePath"`
+}
+
+// MavenDependency represents one POM dependency.
+type MavenDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Classifier string `xml:"classifier"`
+	Scope      string `xml:"scope"`
+}
+
+// MavenProperties represents POM properties (arbitrary '<name>value</name>' elements).
+type MavenProperties map[string]string
+
+// UnmarshalXML is used to decode arbitrary properties elements into the map.
+func (mp *MavenProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	props := MavenProperties{}
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := t.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &el); err != nil {
+				return err
+			}
+			props[el.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			*mp = props
+			return nil
+		}
+	}
+}
+
+// Requirements method always returns nil values, because maven doesnt support locked deps lists.
+func (c MavenParser) Requirements(ctx context.Context) ([]Requirement, error) {
+	return nil, nil
+}
+
+// Constraints method returns pom.xml dependencies constraints.
+//
+// Constraints are collected from dependencies and dependencyManagement sections of the POM and
+// of it's parents (resolved by the 'relativePath'), names are formatted as 'groupId:artifactId'.
+// Properties references are interpolated, dependencies with unresolvable versions and
+// test scoped dependencies are skipped.
+func (c MavenParser) Constraints(ctx context.Context) ([]Constraint, error) {
+	poms, err := c.hierarchy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Properties and managed versions are inherited from the top-most parent to the project itself
+	props := map[string]string{}
+	managed := map[string]MavenDependency{}
+	var managedKeys []string
+	var deps []MavenDependency
+	for i := len(poms) - 1; i >= 0; i-- {
+		pom := poms[i]
+		for k, v := range pom.Properties {
+			props[k] = v
+		}
+		for k, v := range pom.projectProperties() {
+			props[k] = v
+		}
+		for _, dep := range pom.DependencyManagement {
+			if _, ok := managed[dep.key()]; !ok {
+				managedKeys = append(managedKeys, dep.key())
+			}
+			managed[dep.key()] = dep
+		}
+		deps = append(deps, pom.Dependencies...)
+	}
+
+	res := []Constraint{}
+	seen := map[Constraint]struct{}{}
+	add := func(dep MavenDependency) {
+		cns := Constraint{
+			Name:    interpolateMaven(dep.GroupID, props) + ":" + interpolateMaven(dep.ArtifactID, props),
+			Version: interpolateMaven(dep.Version, props),
+		}
+		if cns.Version == "" || strings.Contains(cns.Version, "${") || strings.Contains(cns.Name, "${") {
+			return
+		}
+		if _, ok := seen[cns]; ok {
+			return
+		}
+		seen[cns] = struct{}{}
+		res = append(res, cns)
+	}
+
+	for _, dep := range deps {
+		if dep.Scope == "test" {
+			continue
+		}
+		if dep.Version == "" {
+			if m, ok := managed[dep.key()]; ok {
+				dep.Version = m.Version
+			}
+		}
+		add(dep)
+	}
+	for _, key := range managedKeys {
+		dep := managed[key]
+		if dep.Scope == "test" {
+			continue
+		}
+		add(dep)
+	}
+
+	return res, nil
+}
+
+// hierarchy returns the project POM followed by all of it's parents which could be resolved from the source.
+func (c MavenParser) hierarchy(ctx context.Context) ([]*MavenPom, error) {
+	pom, err := c.pom(ctx, c.SourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	poms := []*MavenPom{pom}
+	filename := c.SourceName
+	for len(poms) <= mavenMaxParents && pom.Parent != nil {
+		relPath := "../pom.xml"
+		if pom.Parent.RelativePath != nil {
+			relPath = strings.TrimSpace(*pom.Parent.RelativePath)
+		}
+		if relPath == "" {
+			break
+		}
+		parentName := path.Join(path.Dir(filename), relPath)
+		if !strings.HasSuffix(parentName, ".xml") {
+			parentName = path.Join(parentName, "pom.xml")
+		}
+		if strings.HasPrefix(parentName, "../") {
+			break // parent is outside of the source
+		}
+
+		parent, err := c.pom(ctx, parentName)
+		if err != nil {
+			if err == ErrFileNotFound {
+				break
+			}
+			return nil, err
+		}
+		// Parent from the relative path must be the declared one, otherwise it is in the repositories
+		if parent.effectiveGroupID() != pom.Parent.GroupID || parent.ArtifactID != pom.Parent.ArtifactID {
+			break
+		}
+
+		poms = append(poms, parent)
+		pom, filename = parent, parentName
+	}
+
+	return poms, nil
+}
+
+// pom fetches and decodes POM file.
+func (c MavenParser) pom(ctx context.Context, filename string) (*MavenPom, error) {
+	b, err := c.fetcher.FileContent(ctx, filename)
+	if err != nil {
+		if err == fetchers.ErrFileNotFound {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("unable to fetch maven dependencies from the source: %w", err)
+	}
+
+	var pom MavenPom
+	if err = xml.Unmarshal(b, &pom); err != nil {
+		return nil, fmt.Errorf("unable to parse maven pom file content: %w", err)
+	}
+
+	return &pom, nil
+}
+
+// effectiveGroupID returns project groupId, which is inherited from the parent if omitted.
+func (mp MavenPom) effectiveGroupID() string {
+	if mp.GroupID == "" && mp.Parent != nil {
+		return mp.Parent.GroupID
+	}
+	return mp.GroupID
+}
+
+// effectiveVersion returns project version, which is inherited from the parent if omitted.
+func (mp MavenPom) effectiveVersion() string {
+	if mp.Version == "" && mp.Parent != nil {
+		return mp.Parent.Version
+	}
+	return mp.Version
+}
+
+// projectProperties returns built-in project properties available for interpolation.
+func (mp MavenPom) projectProperties() map[string]string {
+	props := map[string]string{
+		"project.groupId":    mp.effectiveGroupID(),
+		"project.artifactId": mp.ArtifactID,
+		"project.version":    mp.effectiveVersion(),
+		"pom.groupId":        mp.effectiveGroupID(),
+		"pom.artifactId":     mp.ArtifactID,
+		"pom.version":        mp.effectiveVersion(),
+	}
+	if mp.Parent != nil {
+		props["project.parent.groupId"] = mp.Parent.GroupID
+		props["project.parent.artifactId"] = mp.Parent.ArtifactID
+		props["project.parent.version"] = mp.Parent.Version
+	}
+	return props
+}
+
+// key returns dependency management key ('groupId:artifactId:type:classifier').
+func (md MavenDependency) key() string {
+	typ := md.Type
+	if typ == "" {
+		typ = "jar"
+	}
+	return strings.Join([]string{md.GroupID, md.ArtifactID, typ, md.Classifier}, ":")
+}
+
+// interpolateMaven replaces properties references with their values (nested references are supported).
+func interpolateMaven(value string, props map[string]string) string {
+	value = strings.TrimSpace(value)
+	for i := 0; i < mavenMaxParents && strings.Contains(value, "${"); i++ {
+		replaced := mavenPropertyRgx.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := props[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+		if replaced == value {
+			break
+		}
+		value = replaced
+	}
+	return value
+}
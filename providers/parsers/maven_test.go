@@ -0,0 +1,207 @@
+package parsers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dephub/dephub-core/providers/fetchers"
+)
+
+func TestMavenConstraintsMethod(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"pom.xml":     []byte(mavenParentPomFixture),
+		"app/pom.xml": []byte(mavenPomFixture),
+	}}
+	parser := NewMavenParser(bf, "app/pom.xml")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on maven constraints call : %v", err)
+	}
+
+	expectedConstraints := []Constraint{
+		{Name: "org.springframework:spring-core", Version: "5.3.9"},
+		{Name: "com.google.guava:guava", Version: "31.0.1-jre"},
+		{Name: "com.example:shared", Version: "1.2.0"},
+		{Name: "org.slf4j:slf4j-api", Version: "[1.7,2.0)"},
+		{Name: "com.fasterxml.jackson.core:jackson-databind", Version: "2.12.5"},
+	}
+
+	// Sort before DeepEqual test
+	sort.Slice(cns, func(i, j int) bool {
+		return cns[i].Name > cns[j].Name
+	})
+	sort.Slice(expectedConstraints, func(i, j int) bool {
+		return expectedConstraints[i].Name > expectedConstraints[j].Name
+	})
+
+	if !reflect.DeepEqual(cns, expectedConstraints) {
+		t.Errorf("unexpected maven constraints, got: '%+v", cns)
+	}
+}
+
+func TestMavenConstraintsMethod_ParentOutsideSource(t *testing.T) {
+	bf := fetchers.ByteMapFetcher{Files: map[string][]byte{
+		"pom.xml": []byte(`
+			<project>
+				<parent>
+					<groupId>org.springframework.boot</groupId>
+					<artifactId>spring-boot-starter-parent</artifactId>
+					<version>2.5.5</version>
+					<relativePath/>
+				</parent>
+				<artifactId>app</artifactId>
+				<dependencies>
+					<dependency>
+						<groupId>org.springframework.boot</groupId>
+						<artifactId>spring-boot-starter-web</artifactId>
+					</dependency>
+					<dependency>
+						<groupId>${project.parent.groupId}</groupId>
+						<artifactId>spring-boot-devtools</artifactId>
+						<version>${project.parent.version}</version>
+					</dependency>
+				</dependencies>
+			</project>
+		`),
+	}}
+	parser := NewMavenParser(bf, "")
+
+	cns, err := parser.Constraints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on maven constraints call : %v", err)
+	}
+
+	expectedConstraints := []Constraint{
+		{Name: "org.springframework.boot:spring-boot-devtools", Version: "2.5.5"},
+	}
+	if !reflect.DeepEqual(cns, expectedConstraints) {
+		t.Errorf("unexpected maven constraints, got: '%+v", cns)
+	}
+}
+
+func TestMavenConstraintsMethod_Errors(t *testing.T) {
+	// Table test cases
+	cases := []struct {
+		Name  string
+		Files map[string][]byte
+		Err   string
+	}{
+		{"missing", map[string][]byte{"blablabla": []byte("")}, ErrFileNotFound.Error()},
+		{"broken", map[string][]byte{"pom.xml": []byte("<project><dependencies>")}, "unable to parse maven pom file content"},
+		{"broken parent", map[string][]byte{
+			"pom.xml":     []byte("<project"),
+			"app/pom.xml": []byte("<project><parent><groupId>a</groupId></parent></project>"),
+		}, "unable to parse maven pom file content"},
+	}
+
+	for _, v := range cases {
+		t.Run(v.Name, func(t *testing.T) {
+			bf := fetchers.ByteMapFetcher{Files: v.Files}
+			filename := ""
+			if _, ok := v.Files["app/pom.xml"]; ok {
+				filename = "app/pom.xml"
+			}
+			parser := NewMavenParser(bf, filename)
+
+			cns, err := parser.Constraints(context.Background())
+			if err == nil || !strings.Contains(err.Error(), v.Err) {
+				t.Errorf("expected error %q, got: %v", v.Err, err)
+			}
+			if cns != nil {
+				t.Errorf("expected nil constraints, got: %+v", cns)
+			}
+		})
+	}
+}
+
+func TestMavenRequirementsMethod(t *testing.T) {
+	parser := NewMavenParser(fetchers.ByteMapFetcher{}, "")
+
+	reqs, err := parser.Requirements(context.Background())
+	if reqs != nil || err != nil {
+		t.Errorf("expected nil requirements and error, got: %+v, %v", reqs, err)
+	}
+}
+
+var mavenParentPomFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+	<modelVersion>4.0.0</modelVersion>
+	<groupId>com.example</groupId>
+	<artifactId>parent</artifactId>
+	<version>1.2.0</version>
+	<packaging>pom</packaging>
+
+	<properties>
+		<spring.version>5.3.9</spring.version>
+		<jackson.version>2.12.5</jackson.version>
+	</properties>
+
+	<dependencyManagement>
+		<dependencies>
+			<dependency>
+				<groupId>com.google.guava</groupId>
+				<artifactId>guava</artifactId>
+				<version>31.0.1-jre</version>
+			</dependency>
+			<dependency>
+				<groupId>com.fasterxml.jackson.core</groupId>
+				<artifactId>jackson-databind</artifactId>
+				<version>${jackson.version}</version>
+			</dependency>
+		</dependencies>
+	</dependencyManagement>
+</project>
+`
+
+var mavenPomFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+	<modelVersion>4.0.0</modelVersion>
+	<parent>
+		<groupId>com.example</groupId>
+		<artifactId>parent</artifactId>
+		<version>1.2.0</version>
+	</parent>
+	<artifactId>app</artifactId>
+
+	<properties>
+		<slf4j.range>[1.7,2.0)</slf4j.range>
+	</properties>
+
+	<dependencies>
+		<dependency>
+			<groupId>org.springframework</groupId>
+			<artifactId>spring-core</artifactId>
+			<version>${spring.version}</version>
+		</dependency>
+		<dependency>
+			<groupId>com.google.guava</groupId>
+			<artifactId>guava</artifactId>
+		</dependency>
+		<dependency>
+			<groupId>${project.groupId}</groupId>
+			<artifactId>shared</artifactId>
+			<version>${project.version}</version>
+		</dependency>
+		<dependency>
+			<groupId>org.slf4j</groupId>
+			<artifactId>slf4j-api</artifactId>
+			<version>${slf4j.range}</version>
+		</dependency>
+		<dependency>
+			<groupId>org.unknown</groupId>
+			<artifactId>unknown</artifactId>
+			<version>${unknown.version}</version>
+		</dependency>
+		<dependency>
+			<groupId>junit</groupId>
+			<artifactId>junit</artifactId>
+			<version>4.13.2</version>
+			<scope>test</scope>
+		</dependency>
+	</dependencies>
+</project>
+`
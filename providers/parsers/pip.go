@@ -9,6 +9,7 @@ import (
 	"unicode"
 
 	"github.com/dephub/dephub-core/providers/fetchers"
+	"github.com/dephub/dephub-core/providers/versioneer"
 )
 
 // NewPipParser constructs pip files parser.
@@ -36,6 +37,8 @@ func (c PipParser) Requirements(ctx context.Context) ([]Requirement, error) {
 }
 
 // Constraints method returns python dependencies constraints.
+//
+// Conda environment files (e.g. 'environment.yml') are supported as well, only their nested pip dependencies are used.
 func (c PipParser) Constraints(ctx context.Context) ([]Constraint, error) {
 	b, err := c.fetcher.FileContent(ctx, c.SourceName)
 	if err != nil {
@@ -45,23 +48,86 @@ func (c PipParser) Constraints(ctx context.Context) ([]Constraint, error) {
 		return nil, fmt.Errorf("unable to fetch python(pip) dependencies from the source: %w", err)
 	}
 
-	reqs := parseRequirementsTxt(b)
-	res := []Constraint{}
+	// Conda environment files contain pip dependencies in the nested 'pip' list
+	if IsCondaEnvironment(c.SourceName) {
+		env, err := parseCondaEnvironment(b)
+		if err != nil {
+			return nil, err
+		}
+		b = []byte(strings.Join(env.PipDependencies(), "\n"))
+	}
+
+	return parseRequirementsTxt(b), nil
+}
 
-	for k, v := range reqs {
-		res = append(res, Constraint{
-			Name:    k,
-			Version: v,
-		})
+// PipIndexes represents package indexes options of the requirements file.
+type PipIndexes struct {
+	// IndexURL is '--index-url' ('-i') option value, empty if the file doesn't override the default index.
+	IndexURL string
+	// ExtraIndexURLs are '--extra-index-url' options values in the file order.
+	ExtraIndexURLs []string
+}
+
+// Indexes method returns package indexes options ('--index-url' and '--extra-index-url') of the requirements file.
+func (c PipParser) Indexes(ctx context.Context) (*PipIndexes, error) {
+	if IsCondaEnvironment(c.SourceName) {
+		return &PipIndexes{}, nil
+	}
+	b, err := c.fetcher.FileContent(ctx, c.SourceName)
+	if err != nil {
+		if err == fetchers.ErrFileNotFound {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("unable to fetch python(pip) dependencies from the source: %w", err)
 	}
 
-	return res, nil
+	return parsePipIndexes(b), nil
+}
+
+// parsePipIndexes - helper to parse requirements.txt indexes options (both 'opt value' and 'opt=value' forms).
+func parsePipIndexes(fileContent []byte) *PipIndexes {
+	res := &PipIndexes{}
+	scanner := bufio.NewScanner(bytes.NewReader(fileContent))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(strings.Split(line, " #")[0])
+		for i := 0; i < len(fields); i++ {
+			opt, value := fields[i], ""
+			switch {
+			case strings.HasPrefix(opt, "--") && strings.Contains(opt, "="):
+				parts := strings.SplitN(opt, "=", 2)
+				opt, value = parts[0], parts[1]
+			case strings.HasPrefix(opt, "-i") && !strings.HasPrefix(opt, "-i=") && len(opt) > 2:
+				opt, value = "-i", opt[2:]
+			case i+1 < len(fields):
+				value = fields[i+1]
+				if opt == "-i" || opt == "--index-url" || opt == "--extra-index-url" {
+					i++
+				}
+			}
+
+			switch opt {
+			case "-i", "--index-url":
+				res.IndexURL = value
+			case "--extra-index-url":
+				res.ExtraIndexURLs = append(res.ExtraIndexURLs, value)
+			}
+		}
+	}
+	return res
 }
 
 // parseRequirementsTxt contains requirements.txt files parsing logic.
+//
+// Packages are matched by their canonical names (PEP 503), so 'Django' and 'django' are the same package:
+// the first spelling is kept for display and the last constraint wins. Constraints are returned in the file order.
 // TODO: improve add additional signatures support.
-func parseRequirementsTxt(fileContent []byte) map[string]string {
-	var result map[string]string = make(map[string]string)
+func parseRequirementsTxt(fileContent []byte) []Constraint {
+	result := []Constraint{}
+	lookup := map[versioneer.PipPackageName]int{}
 	delimeters := []string{"===", "==", ">=", "<=", "<", ">", "~=", "!="}
 	scanner := bufio.NewScanner(bytes.NewReader(fileContent))
 	for scanner.Scan() {
@@ -85,7 +151,13 @@ func parseRequirementsTxt(fileContent []byte) map[string]string {
 			}
 		}
 
-		result[pkg] = version
+		name := versioneer.NormalizePipName(pkg)
+		if i, ok := lookup[name]; ok {
+			result[i].Version = version
+			continue
+		}
+		lookup[name] = len(result)
+		result = append(result, Constraint{Name: pkg, Version: version})
 	}
 
 	return result
@@ -0,0 +1,48 @@
+package transport
+
+import "net/http"
+
+// Credentials represents HTTP authentication credentials.
+type Credentials struct {
+	Username string
+	Password string
+	// Token is sent as the bearer token, Username and Password are used for basic authentication if it's empty.
+	Token string
+}
+
+// AuthTransport adds the credentials to the requests sent to the single host, requests to other hosts
+// (e.g. redirects to the files CDN) and requests with 'Authorization' header are sent as is.
+type AuthTransport struct {
+	// Base is the wrapped transport, http.DefaultTransport is used if it's nil.
+	Base        http.RoundTripper
+	Host        string
+	Credentials Credentials
+}
+
+// NewAuthClient returns the copy of httpClient (http.DefaultClient if it's nil) sending the credentials to the host only.
+func NewAuthClient(httpClient *http.Client, host string, creds Credentials) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	cl := *httpClient
+	cl.Transport = &AuthTransport{Base: httpClient.Transport, Host: host, Credentials: creds}
+	return &cl
+}
+
+// RoundTrip sends the request with the credentials (if it's sent to the host).
+func (at *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := at.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.URL.Host != at.Host || req.Header.Get("Authorization") != "" {
+		return base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	if at.Credentials.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+at.Credentials.Token)
+	} else {
+		req.SetBasicAuth(at.Credentials.Username, at.Credentials.Password)
+	}
+	return base.RoundTrip(req)
+}
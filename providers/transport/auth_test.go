@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAuthTransport(t *testing.T) {
+	var auth string
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		auth = r.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+	})
+
+	cases := []struct {
+		Name     string
+		URL      string
+		Header   string
+		Creds    Credentials
+		Expected string
+	}{
+		{"basic", "https://repo.example.com/packages.json", "", Credentials{Username: "user", Password: "secret"}, "Basic dXNlcjpzZWNyZXQ="},
+		{"bearer", "https://repo.example.com/packages.json", "", Credentials{Username: "user", Token: "token"}, "Bearer token"},
+		{"other host", "https://cdn.example.com/file.zip", "", Credentials{Token: "token"}, ""},
+		{"explicit header", "https://repo.example.com/packages.json", "Bearer other", Credentials{Token: "token"}, "Bearer other"},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Name, func(t *testing.T) {
+			cl := &http.Client{Transport: base}
+			cl = NewAuthClient(cl, "repo.example.com", cs.Creds)
+			req, _ := http.NewRequest("GET", cs.URL, nil)
+			if cs.Header != "" {
+				req.Header.Set("Authorization", cs.Header)
+			}
+			resp, err := cl.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if auth != cs.Expected {
+				t.Errorf("expected %q authorization, got %q", cs.Expected, auth)
+			}
+			if cs.Header == "" && req.Header.Get("Authorization") != "" {
+				t.Errorf("expected original request not to be modified")
+			}
+		})
+	}
+}
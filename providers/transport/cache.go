@@ -0,0 +1,369 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// XFromCache is the header set on responses served from the cache (including revalidated ones).
+const XFromCache = "X-From-Cache"
+
+// DefaultMaxCachedSize is the default CachingTransport response body size limit (64 MiB), larger responses are not cached.
+const DefaultMaxCachedSize = 64 << 20
+
+// CachedResponse represents cached HTTP response.
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	// Vary contains the request headers values listed in the response 'Vary' header.
+	Vary map[string]string `json:"vary,omitempty"`
+	// Stored is the time the response was received or revalidated.
+	Stored time.Time `json:"stored"`
+}
+
+// Cache interface defines CachingTransport storage backends, implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// NewMemoryCache constructs in-memory Cache implementation.
+func NewMemoryCache() Cache {
+	return &MemoryCache{entries: map[string]*CachedResponse{}}
+}
+
+// MemoryCache represents in-memory Cache implementation.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]*CachedResponse
+}
+
+// Get returns cached response (if any).
+func (mc *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	resp, ok := mc.entries[key]
+	return resp, ok
+}
+
+// Set stores the response.
+func (mc *MemoryCache) Set(key string, resp *CachedResponse) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.entries[key] = resp
+}
+
+// NewDiskCache constructs on-disk Cache implementation storing responses in the directory (it's created if missing).
+func NewDiskCache(dir string) Cache {
+	return &DiskCache{Dir: dir}
+}
+
+// DiskCache represents on-disk Cache implementation, every response is stored as a JSON file named by the key hash.
+//
+// Storage errors are not reported: unreadable responses are treated as missing and failed writes are skipped.
+type DiskCache struct {
+	Dir string
+}
+
+// Get returns cached response (if any).
+func (dc DiskCache) Get(key string) (*CachedResponse, bool) {
+	b, err := ioutil.ReadFile(dc.filename(key))
+	if err != nil {
+		return nil, false
+	}
+	var resp CachedResponse
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+// Set stores the response, file is replaced atomically.
+func (dc DiskCache) Set(key string, resp *CachedResponse) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(dc.Dir, 0o755); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(dc.Dir, ".response-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dc.filename(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// filename returns the response file path.
+func (dc DiskCache) filename(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dc.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// CachingTransport is http.RoundTripper caching successful GET responses (private cache semantics), it's safe for concurrent use.
+//
+// Fresh responses ('Cache-Control: max-age' or 'Expires') are served from the cache, stale ones are revalidated
+// with conditional requests ('ETag' and 'Last-Modified'). Responses without freshness information and validators,
+// with 'Cache-Control: no-store' or larger than MaxSize are not cached. Caller's own conditional requests
+// ('If-None-Match' or 'If-Modified-Since') bypass the cache, so the caller gets '304 Not Modified' responses as is.
+type CachingTransport struct {
+	// Base is the underlying transport, http.DefaultTransport is used if it's nil.
+	Base  http.RoundTripper
+	Cache Cache
+	// MaxSize is the cached response body size limit in bytes.
+	MaxSize int64
+}
+
+// NewCachingTransport constructs CachingTransport wrapping the base transport, if cache is nil - in-memory cache is used.
+func NewCachingTransport(base http.RoundTripper, cache Cache) *CachingTransport {
+	if cache == nil {
+		cache = NewMemoryCache()
+	}
+	return &CachingTransport{Base: base, Cache: cache, MaxSize: DefaultMaxCachedSize}
+}
+
+// NewCachingClient returns the copy of httpClient (http.DefaultClient if it's nil) using CachingTransport
+// wrapping it's transport, if cache is nil - in-memory cache is used.
+//
+// Use the same cache for many clients (e.g. concurrent checks) to share the responses.
+func NewCachingClient(httpClient *http.Client, cache Cache) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	cl := *httpClient
+	cl.Transport = NewCachingTransport(httpClient.Transport, cache)
+	return &cl
+}
+
+// RoundTrip serves the request from the cache or sends it (conditionally if the cached response is stale).
+func (ct *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := ct.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	reqCC := cacheControl(req.Header)
+	if (req.Method != "" && req.Method != http.MethodGet) || reqCC.has("no-store") || req.Header.Get("Range") != "" {
+		return base.RoundTrip(req)
+	}
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached, ok := ct.Cache.Get(key)
+	if ok && !cached.matches(req) {
+		cached, ok = nil, false
+	}
+	if ok && !reqCC.has("no-cache") && cached.fresh(time.Now()) {
+		return cached.response(req), nil
+	}
+
+	outReq := req
+	if ok {
+		etag, lastModified := cached.Header.Get("ETag"), cached.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			outReq = req.Clone(req.Context())
+			if etag != "" {
+				outReq.Header.Set("If-None-Match", etag)
+			}
+			if lastModified != "" {
+				outReq.Header.Set("If-Modified-Since", lastModified)
+			}
+		}
+	}
+
+	resp, err := base.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified && outReq != req {
+		resp.Body.Close()
+		updated := *cached
+		updated.Header = cached.Header.Clone()
+		for k, v := range resp.Header {
+			updated.Header[k] = v
+		}
+		updated.Stored = time.Now()
+		ct.Cache.Set(key, &updated)
+		return updated.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || !cacheable(resp.Header) {
+		return resp, nil
+	}
+	return ct.store(key, req, resp)
+}
+
+// store reads the response body (within MaxSize limit) and caches the response.
+func (ct *CachingTransport) store(key string, req *http.Request, resp *http.Response) (*http.Response, error) {
+	limit := ct.MaxSize
+	if limit <= 0 {
+		limit = DefaultMaxCachedSize
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		// Too large response is passed through without caching
+		resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	cached := &CachedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body, Stored: time.Now()}
+	for _, name := range varyHeaders(resp.Header) {
+		if cached.Vary == nil {
+			cached.Vary = map[string]string{}
+		}
+		cached.Vary[name] = req.Header.Get(name)
+	}
+	ct.Cache.Set(key, cached)
+	return resp, nil
+}
+
+// cacheKey - helper to get the request cache key, requests with different credentials are cached separately.
+func cacheKey(req *http.Request) string {
+	key := req.URL.String()
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += " " + hex.EncodeToString(sum[:])
+	}
+	return key
+}
+
+// matches reports whether the request headers listed in the response 'Vary' header are the same.
+func (cr *CachedResponse) matches(req *http.Request) bool {
+	for _, name := range varyHeaders(cr.Header) {
+		if name == "*" || req.Header.Get(name) != cr.Vary[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// fresh reports whether the response can be served without revalidation.
+func (cr *CachedResponse) fresh(now time.Time) bool {
+	cc := cacheControl(cr.Header)
+	if cc.has("no-cache") {
+		return false
+	}
+	age := now.Sub(cr.Stored)
+	if v, err := strconv.Atoi(cr.Header.Get("Age")); err == nil {
+		age += time.Duration(v) * time.Second
+	}
+	if maxAge, ok := cc["max-age"]; ok {
+		sec, err := strconv.Atoi(maxAge)
+		return err == nil && age < time.Duration(sec)*time.Second
+	}
+	if expires, err := http.ParseTime(cr.Header.Get("Expires")); err == nil {
+		date, err := http.ParseTime(cr.Header.Get("Date"))
+		if err != nil {
+			date = cr.Stored
+		}
+		return now.Sub(cr.Stored) < expires.Sub(date)
+	}
+	return false
+}
+
+// response constructs http.Response of the cached one.
+func (cr *CachedResponse) response(req *http.Request) *http.Response {
+	header := cr.Header.Clone()
+	header.Set(XFromCache, "1")
+	return &http.Response{
+		Status:        strconv.Itoa(cr.StatusCode) + " " + http.StatusText(cr.StatusCode),
+		StatusCode:    cr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(cr.Body)),
+		ContentLength: int64(len(cr.Body)),
+		Request:       req,
+	}
+}
+
+// cacheable reports whether the response can be stored, it must have freshness information or validators.
+func cacheable(header http.Header) bool {
+	cc := cacheControl(header)
+	if cc.has("no-store") {
+		return false
+	}
+	for _, name := range varyHeaders(header) {
+		if name == "*" {
+			return false
+		}
+	}
+	return cc.has("max-age") || header.Get("Expires") != "" || header.Get("ETag") != "" || header.Get("Last-Modified") != ""
+}
+
+// cacheDirectives represents 'Cache-Control' header directives.
+type cacheDirectives map[string]string
+
+// has reports whether the directive is present.
+func (cd cacheDirectives) has(name string) bool {
+	_, ok := cd[name]
+	return ok
+}
+
+// cacheControl - helper to parse 'Cache-Control' header directives.
+func cacheControl(header http.Header) cacheDirectives {
+	cd := cacheDirectives{}
+	for _, v := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			name, value := part, ""
+			if i := strings.Index(part, "="); i >= 0 {
+				name, value = part[:i], strings.Trim(part[i+1:], `"`)
+			}
+			cd[strings.ToLower(strings.TrimSpace(name))] = value
+		}
+	}
+	return cd
+}
+
+// varyHeaders - helper to get canonical header names listed in the 'Vary' header.
+func varyHeaders(header http.Header) []string {
+	var names []string
+	for _, v := range header.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// readCloser combines the reader and the closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
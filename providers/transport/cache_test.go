@@ -0,0 +1,168 @@
+package transport
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachingTransport(t *testing.T) {
+	var requests, revalidations int32
+	lastModified := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/fresh":
+			rw.Header().Set("Cache-Control", "public, max-age=300")
+		case "/etag":
+			rw.Header().Set("Cache-Control", "no-cache")
+			rw.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				atomic.AddInt32(&revalidations, 1)
+				rw.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/modified":
+			rw.Header().Set("Last-Modified", lastModified)
+			if r.Header.Get("If-Modified-Since") == lastModified {
+				atomic.AddInt32(&revalidations, 1)
+				rw.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/expires":
+			rw.Header().Set("Expires", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		case "/no-store":
+			rw.Header().Set("Cache-Control", "no-store, max-age=300")
+		case "/vary":
+			rw.Header().Set("Cache-Control", "max-age=300")
+			rw.Header().Set("Vary", "Accept")
+		case "/large":
+			rw.Header().Set("Cache-Control", "max-age=300")
+			_, _ = rw.Write([]byte(strings.Repeat("x", 100)))
+			return
+		case "/error":
+			rw.Header().Set("Cache-Control", "max-age=300")
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+		_, _ = rw.Write([]byte("content of " + r.URL.Path + " as " + r.Header.Get("Accept")))
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		Path          string
+		Requests      int32
+		Revalidations int32
+	}{
+		{"/fresh", 1, 0},
+		{"/etag", 3, 2},
+		{"/modified", 3, 2},
+		{"/expires", 1, 0},
+		{"/no-store", 3, 0},
+		{"/large", 3, 0},
+		{"/error", 3, 0},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Path, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+			atomic.StoreInt32(&revalidations, 0)
+			ct := NewCachingTransport(srv.Client().Transport, nil)
+			ct.MaxSize = 50
+			cl := &http.Client{Transport: ct}
+			for i := 0; i < 3; i++ {
+				resp, err := cl.Get(srv.URL + cs.Path)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				body, _ := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				if cs.Path != "/large" && string(body) != "content of "+cs.Path+" as " {
+					t.Errorf("unexpected response body: %q", body)
+				}
+				if cs.Path == "/large" && len(body) != 100 {
+					t.Errorf("unexpected large response body size: %d", len(body))
+				}
+			}
+			if requests != cs.Requests || revalidations != cs.Revalidations {
+				t.Errorf("expected %d requests and %d revalidations, got %d and %d", cs.Requests, cs.Revalidations, requests, revalidations)
+			}
+		})
+	}
+
+	t.Run("/vary", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		cl := NewCachingClient(srv.Client(), nil)
+		for _, accept := range []string{"text/html", "application/json", "application/json"} {
+			req, _ := http.NewRequest("GET", srv.URL+"/vary", nil)
+			req.Header.Set("Accept", accept)
+			resp, err := cl.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != "content of /vary as "+accept {
+				t.Errorf("unexpected response body: %q", body)
+			}
+		}
+		if requests != 2 {
+			t.Errorf("expected 2 requests for 2 accepted types, got %d", requests)
+		}
+	})
+}
+
+func TestCachingTransport_SharedDiskCache(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		rw.Header().Set("Cache-Control", "max-age=300")
+		_, _ = fmt.Fprintf(rw, "package %s", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "dephub-cache")
+	if err != nil {
+		t.Fatalf("unable to create cache directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	cache := NewDiskCache(dir)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cl := NewCachingClient(srv.Client(), cache)
+			resp, err := cl.Get(fmt.Sprintf("%s/%d", srv.URL, i%2))
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}(i)
+	}
+	wg.Wait()
+
+	// Next run uses the stored responses
+	atomic.StoreInt32(&requests, 0)
+	cl := NewCachingClient(srv.Client(), cache)
+	for i := 0; i < 2; i++ {
+		resp, err := cl.Get(fmt.Sprintf("%s/%d", srv.URL, i))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != fmt.Sprintf("package /%d", i) || resp.Header.Get(XFromCache) != "1" {
+			t.Errorf("unexpected cached response: %q, %v", body, resp.Header)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("expected responses to be served from the disk cache, got %d requests", requests)
+	}
+}
@@ -0,0 +1,328 @@
+/*
+Package transport provides rate limit aware HTTP transport shared by API clients and fetchers.
+
+Usage:
+
+	client := transport.NewClient(http.DefaultClient, &transport.Options{RequestsPerSecond: 5})
+	api, err := packagist.NewClient(client, nil)
+*/
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned (wrapped into RateLimitError) when the server rate limit is exceeded.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitError represents the exceeded server rate limit, it matches ErrRateLimited.
+type RateLimitError struct {
+	// Host is the rate limited server host.
+	Host string
+	// StatusCode is the HTTP response status code, zero if the request wasn't sent (the limit is known to be exceeded).
+	StatusCode int
+	// Reset is the time the request can be retried after, zero if it's unknown.
+	Reset time.Time
+}
+
+// Error returns the error message.
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("%s: '%s'", ErrRateLimited, e.Host)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" responded with HTTP error '%d: %s'", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if !e.Reset.IsZero() {
+		msg += fmt.Sprintf(", retry after %s", e.Reset.Format(time.RFC3339))
+	}
+	return msg
+}
+
+// Is reports whether the target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// CheckRateLimit returns RateLimitError if the response reports the exceeded rate limit
+// (429 status code or 403 status code with exhausted GitHub rate limit), nil otherwise.
+func CheckRateLimit(resp *http.Response) error {
+	if resp.StatusCode != http.StatusTooManyRequests &&
+		(resp.StatusCode != http.StatusForbidden || resp.Header.Get("X-RateLimit-Remaining") != "0") {
+		return nil
+	}
+	e := &RateLimitError{StatusCode: resp.StatusCode, Reset: ResetTime(resp.Header)}
+	if resp.Request != nil {
+		e.Host = resp.Request.URL.Host
+	}
+	return e
+}
+
+// ResetTime returns the time after which the request can be retried from 'Retry-After'
+// or GitHub 'X-RateLimit-Reset' response headers, zero time is returned if there are none.
+func ResetTime(header http.Header) time.Time {
+	if v := header.Get("Retry-After"); v != "" {
+		if sec, err := strconv.Atoi(v); err == nil {
+			return time.Now().Add(time.Duration(sec) * time.Second)
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return t
+		}
+	}
+	if v := header.Get("X-RateLimit-Reset"); v != "" {
+		if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(sec, 0)
+		}
+	}
+	return time.Time{}
+}
+
+// Options represents Transport options, zero values mean defaults.
+type Options struct {
+	// MaxRetries is the idempotent requests retries count, default is 3, negative value disables retries.
+	MaxRetries int
+	// MinBackoff is the first retry delay, default is 500ms. Delay is doubled (and jittered) on every retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the retry delay limit, default is 30s.
+	MaxBackoff time.Duration
+	// MaxWait is the longest rate limit reset time the request waits for before it's retried,
+	// default is 1m. Rate limited requests with later reset time fail with RateLimitError.
+	MaxWait time.Duration
+	// RequestsPerSecond is the client-side requests rate limit per host, zero value means no limit.
+	RequestsPerSecond float64
+	// Burst is the requests count allowed to be sent at once per host, default is 1.
+	Burst int
+}
+
+// DefaultOptions are the options used by DefaultClient.
+var DefaultOptions = Options{RequestsPerSecond: 10, Burst: 20}
+
+// DefaultClient is the shared rate limit aware client based on http.DefaultTransport.
+var DefaultClient = NewClient(nil, &DefaultOptions)
+
+// Transport is rate limit aware http.RoundTripper, it's safe for concurrent use.
+//
+// Requests are limited per host with the token bucket, idempotent requests are retried on network errors,
+// 5xx and rate limit responses with jittered exponential backoff ('Retry-After' and GitHub rate limit headers
+// are honored). Once the server reports the exhausted rate limit, requests to the host fail with RateLimitError
+// without being sent until the reset time (unless it's within MaxWait).
+type Transport struct {
+	// Base is the underlying transport, http.DefaultTransport is used if it's nil.
+	Base http.RoundTripper
+	opts Options
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+// hostState represents the host requests limits.
+type hostState struct {
+	mu sync.Mutex
+	// tokens - available requests count (negative if requests are waiting for tokens)
+	tokens float64
+	last   time.Time
+	// blockedUntil - server rate limit reset time
+	blockedUntil time.Time
+}
+
+// New constructs Transport wrapping the base transport, if opts is nil - default options are used.
+func New(base http.RoundTripper, opts *Options) *Transport {
+	t := &Transport{Base: base, hosts: map[string]*hostState{}}
+	if opts != nil {
+		t.opts = *opts
+	}
+	if t.opts.MaxRetries == 0 {
+		t.opts.MaxRetries = 3
+	}
+	if t.opts.MaxRetries < 0 {
+		t.opts.MaxRetries = 0
+	}
+	if t.opts.MinBackoff <= 0 {
+		t.opts.MinBackoff = 500 * time.Millisecond
+	}
+	if t.opts.MaxBackoff <= 0 {
+		t.opts.MaxBackoff = 30 * time.Second
+	}
+	if t.opts.MaxWait <= 0 {
+		t.opts.MaxWait = time.Minute
+	}
+	if t.opts.Burst <= 0 {
+		t.opts.Burst = 1
+	}
+	return t
+}
+
+// NewClient returns the copy of httpClient (http.DefaultClient if it's nil) using Transport
+// wrapping it's transport, if opts is nil - default options are used.
+func NewClient(httpClient *http.Client, opts *Options) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	cl := *httpClient
+	cl.Transport = New(httpClient.Transport, opts)
+	return &cl
+}
+
+// RoundTrip sends the request within the host limits retrying it if needed.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	host := t.host(req.URL.Host)
+	retryable := isIdempotent(req.Method) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req.Context(), req.URL.Host, host); err != nil {
+			return nil, err
+		}
+
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+		resp, err := base.RoundTrip(r)
+
+		var reset time.Time
+		switch {
+		case err != nil:
+			if req.Context().Err() != nil || !retryable || attempt >= t.opts.MaxRetries {
+				return nil, err
+			}
+		case CheckRateLimit(resp) != nil:
+			reset = ResetTime(resp.Header)
+			host.block(reset)
+			if !retryable || attempt >= t.opts.MaxRetries || time.Until(reset) > t.opts.MaxWait {
+				return resp, nil
+			}
+			resp.Body.Close()
+		case resp.StatusCode >= 500 && retryable && attempt < t.opts.MaxRetries:
+			// Server asked to retry later than we are allowed to wait ('Retry-After'), the response is returned as is
+			reset = ResetTime(resp.Header)
+			if time.Until(reset) > t.opts.MaxWait {
+				return resp, nil
+			}
+			resp.Body.Close()
+		default:
+			if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+				host.block(ResetTime(resp.Header))
+			}
+			return resp, nil
+		}
+
+		delay := t.backoff(attempt)
+		if d := time.Until(reset); d > delay {
+			delay = d
+		}
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// host returns the host limits state.
+func (t *Transport) host(name string) *hostState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h, ok := t.hosts[name]
+	if !ok {
+		h = &hostState{tokens: float64(t.opts.Burst), last: time.Now()}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+// wait waits for the host token and server rate limit reset (within MaxWait).
+func (t *Transport) wait(ctx context.Context, name string, h *hostState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	now := time.Now()
+	if h.blockedUntil.After(now) {
+		until := h.blockedUntil
+		h.mu.Unlock()
+		if until.Sub(now) > t.opts.MaxWait {
+			return &RateLimitError{Host: name, Reset: until}
+		}
+		if err := sleep(ctx, until.Sub(now)); err != nil {
+			return err
+		}
+		h.mu.Lock()
+		now = time.Now()
+	}
+	if t.opts.RequestsPerSecond <= 0 {
+		h.mu.Unlock()
+		return nil
+	}
+
+	h.tokens += now.Sub(h.last).Seconds() * t.opts.RequestsPerSecond
+	if h.tokens > float64(t.opts.Burst) {
+		h.tokens = float64(t.opts.Burst)
+	}
+	h.last = now
+	h.tokens--
+	delay := time.Duration(-h.tokens / t.opts.RequestsPerSecond * float64(time.Second))
+	h.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		// Cancelled request gives it's token back
+		h.mu.Lock()
+		h.tokens++
+		h.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// block blocks requests to the host until the reset time.
+func (h *hostState) block(reset time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if reset.After(h.blockedUntil) {
+		h.blockedUntil = reset
+	}
+}
+
+// backoff returns jittered exponential retry delay.
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.opts.MinBackoff << uint(attempt)
+	if d > t.opts.MaxBackoff || d <= 0 {
+		d = t.opts.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// isIdempotent reports whether the HTTP method is idempotent (safe to retry).
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// sleep - helper to wait for the duration or the context cancellation.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
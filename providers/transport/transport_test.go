@@ -0,0 +1,172 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testOptions are the options with short delays.
+var testOptions = &Options{MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestTransport_Retries(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		switch {
+		case r.URL.Path == "/unavailable" && n < 3:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/limited" && n < 2:
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/broken":
+			rw.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/maintenance":
+			rw.Header().Set("Retry-After", "3600")
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		Path     string
+		Method   string
+		Status   int
+		Requests int32
+	}{
+		{"/unavailable", "GET", http.StatusOK, 3},
+		{"/limited", "GET", http.StatusOK, 2},
+		// Retries count is limited
+		{"/broken", "GET", http.StatusInternalServerError, 4},
+		// Retry-After exceeds MaxWait
+		{"/maintenance", "GET", http.StatusServiceUnavailable, 1},
+		// Not idempotent requests are never retried
+		{"/unavailable", "POST", http.StatusServiceUnavailable, 1},
+	}
+	for _, cs := range cases {
+		t.Run(cs.Method+cs.Path, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+			cl := NewClient(srv.Client(), testOptions)
+			req, _ := http.NewRequest(cs.Method, srv.URL+cs.Path, strings.NewReader(""))
+			resp, err := cl.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != cs.Status || requests != cs.Requests {
+				t.Errorf("expected %d status after %d requests, got %d after %d", cs.Status, cs.Requests, resp.StatusCode, requests)
+			}
+		})
+	}
+}
+
+func TestTransport_NetworkErrors(t *testing.T) {
+	attempts := 0
+	tr := New(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+	}), testOptions)
+
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+	resp, err := tr.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK || attempts != 3 {
+		t.Errorf("expected success after 3 attempts, got %d attempts: %v", attempts, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ = http.NewRequestWithContext(ctx, "GET", "https://example.com/", nil)
+	if _, err = tr.RoundTrip(req); err == nil {
+		t.Errorf("expected error on cancelled request, got none")
+	}
+}
+
+func TestTransport_RateLimitReset(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		rw.Header().Set("X-RateLimit-Remaining", "0")
+		rw.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		rw.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	cl := NewClient(srv.Client(), testOptions)
+	resp, err := cl.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	rateErr := CheckRateLimit(resp)
+	if !errors.Is(rateErr, ErrRateLimited) {
+		t.Errorf("expected rate limit error on response, got: %v", rateErr)
+	}
+
+	// Reset time exceeds MaxWait, so the host is blocked without sending requests
+	_, err = cl.Get(srv.URL)
+	var rle *RateLimitError
+	if !errors.As(err, &rle) || !rle.Reset.Equal(reset) || rle.StatusCode != 0 {
+		t.Errorf("expected rate limit error with reset time, got: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected blocked host to get one request, got %d", requests)
+	}
+}
+
+func TestTransport_TokenBucket(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	cl := NewClient(srv.Client(), &Options{RequestsPerSecond: 50, Burst: 2})
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		resp, err := cl.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	// Burst requests are sent at once, 4 more requests take at least 80ms
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("expected requests to be limited, took %s", elapsed)
+	}
+}
+
+func TestResetTime(t *testing.T) {
+	date := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		Header   http.Header
+		Expected time.Time
+	}{
+		{http.Header{"Retry-After": {date.Format(http.TimeFormat)}}, date},
+		{http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(date.Unix(), 10)}}, date},
+		{http.Header{"Retry-After": {"invalid"}}, time.Time{}},
+		{http.Header{}, time.Time{}},
+	}
+	for _, cs := range cases {
+		if reset := ResetTime(cs.Header); !reset.Equal(cs.Expected) {
+			t.Errorf("unexpected reset time for %v: %s", cs.Header, reset)
+		}
+	}
+
+	if reset := ResetTime(http.Header{"Retry-After": {"120"}}); time.Until(reset) < time.Minute {
+		t.Errorf("unexpected reset time for delay seconds: %s", reset)
+	}
+}
+
+// roundTripFunc is the http.RoundTripper function adapter.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,375 @@
+package versioneer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+Cargo versions and constraints semantic parsing implementation.
+
+Cargo uses SemVer 2.0 versions, and requirements without an operator are
+treated as caret requirements (e.g. '1.2.3' is the same as '^1.2.3').
+*/
+
+// cargoOprFunc represents cargo constraint operator check function.
+// It returns true if the version is satisfied by the constraint.
+type cargoOprFunc func(v Version, c cargoConstraint) bool
+
+// cargoConfig is used to store cargo parser configuration.
+type cargoConfig struct {
+	operators              map[string]cargoOprFunc // List of supported constraints operators mapped to check functions (e.g. '>=')
+	versionRgx             string                  // cargo version regexp (e.g. 1.2.3-alpha.1)
+	wildcardRgx            string                  // cargo wildcard version regexp (e.g. 1.2.*)
+	constraintsRgxCompiled *regexp.Regexp          // Compiled cargo constraint+wildcard regexp
+	versionRgxCompiled     *regexp.Regexp          // Compiled version regexp
+}
+
+// cargoCfg is a global cargo parser configuration.
+var cargoCfg cargoConfig
+
+// Cargo parser config initialization and expressions compiling.
+func init() {
+	cargoCfg.versionRgx = `([0-9]+)\.([0-9]+)\.([0-9]+)(-([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?(\+([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?`
+	cargoCfg.wildcardRgx = `([0-9]+|x|X|\*)(\.[0-9]+|\.x|\.X|\.\*)?(\.[0-9]+|\.x|\.X|\.\*)?(-([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?(\+([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?`
+	// Supported cargo constraints operators
+	cargoCfg.operators = map[string]cargoOprFunc{
+		"":   cargoConstraintCaret, // default requirement is a caret one
+		"=":  cargoConstraintEqual,
+		">":  cargoConstraintGreaterThan,
+		"<":  cargoConstraintLessThan,
+		">=": cargoConstraintGreaterThanEqual,
+		"<=": cargoConstraintLessThanEqual,
+		"~":  cargoConstraintTilde,
+		"^":  cargoConstraintCaret,
+	}
+
+	// Convert all existing convertion options into escaped regex words
+	ops := make([]string, 0, len(cargoCfg.operators))
+	for k := range cargoCfg.operators {
+		ops = append(ops, regexp.QuoteMeta(k))
+	}
+	cargoCfg.constraintsRgxCompiled = regexp.MustCompile(fmt.Sprintf(`^\s*(%s)\s*(%s)\s*$`, strings.Join(ops, "|"), cargoCfg.wildcardRgx))
+	cargoCfg.versionRgxCompiled = regexp.MustCompile("^" + cargoCfg.versionRgx + "$")
+}
+
+func cargoConstraintEqual(v Version, c cargoConstraint) bool {
+	switch c.wildcard {
+	case wildcardNone:
+		return cargoCompare(v, c.ver) == 0 // fully equal
+	case wildcardMajor:
+		return true // * is always equal to any version
+	case wildcardMinor:
+		return v.Major() == c.ver.Major() // major equal
+	case wildcardPatch:
+		return v.Major() == c.ver.Major() && v.Minor() == c.ver.Minor() // major equal, minor equal
+	}
+	return false
+}
+
+func cargoConstraintGreaterThan(v Version, c cargoConstraint) bool {
+	// Partial versions are treated as ranges, so '>1.2' means '>=1.3.0'
+	switch c.wildcard {
+	case wildcardNone:
+		return cargoCompare(v, c.ver) > 0
+	case wildcardMinor:
+		return v.Major() > c.ver.Major()
+	case wildcardPatch:
+		return v.Major() > c.ver.Major() || (v.Major() == c.ver.Major() && v.Minor() > c.ver.Minor())
+	}
+	return false
+}
+
+func cargoConstraintLessThan(v Version, c cargoConstraint) bool {
+	// Missing segments are zeros, so '<1.2' means '<1.2.0'
+	return cargoCompare(v, c.ver) < 0
+}
+
+func cargoConstraintGreaterThanEqual(v Version, c cargoConstraint) bool {
+	// Missing segments are zeros, so '>=1.2' means '>=1.2.0'
+	return cargoCompare(v, c.ver) >= 0
+}
+
+func cargoConstraintLessThanEqual(v Version, c cargoConstraint) bool {
+	return cargoConstraintEqual(v, c) || cargoConstraintLessThan(v, c)
+}
+
+func cargoConstraintTilde(v Version, c cargoConstraint) bool {
+	// Return false on less versions.
+	if cargoConstraintLessThan(v, c) {
+		return false
+	}
+
+	switch c.wildcard {
+	case wildcardNone, wildcardPatch:
+		return v.Major() == c.ver.Major() && v.Minor() == c.ver.Minor() // '~1.2.3' and '~1.2' are '<1.3.0'
+	case wildcardMinor:
+		return v.Major() == c.ver.Major() // '~1' is '<2.0.0'
+	}
+	return true
+}
+
+func cargoConstraintCaret(v Version, c cargoConstraint) bool {
+	// Return false on less versions.
+	if cargoConstraintLessThan(v, c) {
+		return false
+	}
+
+	// Caret allows updates which do not modify the left-most non-zero segment.
+	switch true {
+	case c.wildcard == wildcardMajor:
+		return true
+	case c.ver.Major() > 0 || c.wildcard == wildcardMinor:
+		return v.Major() == c.ver.Major()
+	case c.ver.Minor() > 0 || c.wildcard == wildcardPatch:
+		return v.Major() == c.ver.Major() && v.Minor() == c.ver.Minor()
+	}
+	// '^0.0.3' is basically '=0.0.3'
+	return v.Major() == c.ver.Major() && v.Minor() == c.ver.Minor() && v.Patch() == c.ver.Patch()
+}
+
+// cargoCompare compares two versions including their pre-release identifiers.
+//
+// It returns 0 if the versions are equal, -1 if a is less than b and +1 if a is greater than b.
+func cargoCompare(a, b Version) int {
+	switch true {
+	case a.Major() != b.Major():
+		return compareInts(a.Major(), b.Major())
+	case a.Minor() != b.Minor():
+		return compareInts(a.Minor(), b.Minor())
+	case a.Patch() != b.Patch():
+		return compareInts(a.Patch(), b.Patch())
+	}
+	return comparePrerelease(cargoPrerelease(a), cargoPrerelease(b))
+}
+
+// cargoPrerelease returns pre-release part of the version (if any).
+func cargoPrerelease(v Version) string {
+	if cv, ok := v.(CargoVersion); ok {
+		return cv.pre
+	}
+	return ""
+}
+
+// compareInts returns -1, 0 or +1 depending on the integers order.
+func compareInts(a, b int) int {
+	switch true {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease compares SemVer pre-release parts (e.g. 'alpha.1' and 'beta').
+//
+// Version without a pre-release has higher precedence than the one with it.
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	aIds, bIds := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIds) && i < len(bIds); i++ {
+		aNum, aErr := strconv.Atoi(aIds[i])
+		bNum, bErr := strconv.Atoi(bIds[i])
+		switch true {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return compareInts(aNum, bNum)
+			}
+		case aErr == nil:
+			return -1 // numeric identifiers have lower precedence
+		case bErr == nil:
+			return 1
+		case aIds[i] != bIds[i]:
+			if aIds[i] < bIds[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return compareInts(len(aIds), len(bIds))
+}
+
+// NewCargoVersion constructs ready-to-use cargo Version instance.
+func NewCargoVersion(value string) (Version, error) {
+	matches := cargoCfg.versionRgxCompiled.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return nil, fmt.Errorf("version '%s' is not supported", value)
+	}
+
+	sv := CargoVersion{value: value, pre: matches[5]}
+	for i, segment := range []*int{&sv.major, &sv.minor, &sv.patch} {
+		temp, err := strconv.ParseInt(matches[i+1], 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("segment parse error: %s", err)
+		}
+		*segment = int(temp)
+	}
+
+	return sv, nil
+}
+
+// NewCargoConstraints constructs ready-to-use cargo Constraints instance.
+func NewCargoConstraints(value string) (Constraints, error) {
+	// https://doc.rust-lang.org/cargo/reference/specifying-dependencies.html
+	andsRaw := strings.Split(value, ",")
+	ands := make([]cargoConstraint, len(andsRaw))
+	for k, v := range andsRaw {
+		constraint, err := parseCargoConstraint(v)
+		if err != nil {
+			return nil, err
+		}
+		ands[k] = *constraint
+	}
+	return CargoConstraints{value: value, constraints: ands}, nil
+}
+
+// parseCargoConstraint is a utility function to convert raw string unary constraint into cargoConstraint.
+func parseCargoConstraint(c string) (*cargoConstraint, error) {
+	matches := cargoCfg.constraintsRgxCompiled.FindStringSubmatch(c)
+	if matches == nil {
+		return nil, fmt.Errorf("constraint not supported: %q", c)
+	}
+
+	var (
+		operator            = matches[1] // comparison operator from unary constraint string (e.g. '>=')
+		rawVersion          = matches[2]
+		wildcard            = wildcardNone
+		major, minor, patch = matches[3], strings.TrimPrefix(matches[4], "."), strings.TrimPrefix(matches[5], ".")
+		pre                 = matches[6]
+		version             = fmt.Sprintf("%s.%s.%s%s", major, minor, patch, pre)
+	)
+
+	isWildcard := func(s string) bool { return s == "*" || s == "x" || s == "X" }
+	// Mark constrait as wildcard if we encounter any and then normalize raw version to fixed one
+	if isWildcard(major) {
+		version = "0.0.0"
+		wildcard = wildcardMajor
+	} else if isWildcard(minor) || minor == "" {
+		version = fmt.Sprintf("%s.0.0%s", major, pre)
+		wildcard = wildcardMinor
+	} else if isWildcard(patch) || patch == "" {
+		version = fmt.Sprintf("%s.%s.0%s", major, minor, pre)
+		wildcard = wildcardPatch
+	}
+
+	// Wildcards without an operator are equality requirements ('1.2.*' is '=1.2'), not caret ones.
+	if operator == "" && (isWildcard(major) || isWildcard(minor) || isWildcard(patch)) {
+		operator = "="
+	}
+
+	vrs, err := NewCargoVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse version: %w", err)
+	}
+
+	cc := &cargoConstraint{
+		compare:  cargoCfg.operators[operator],
+		operator: operator,
+		wildcard: wildcard,
+		raw:      rawVersion,
+		ver:      vrs,
+	}
+
+	return cc, nil
+}
+
+// CargoConstraints represent Constraints implementation for Cargo package manager.
+type CargoConstraints struct {
+	value       string
+	constraints []cargoConstraint
+}
+
+// cargoConstraint represent unary constraint (e.g. for '>=1.2, <1.7' one of the constraints is '<1.7')
+type cargoConstraint struct {
+	compare  cargoOprFunc // func used to compare this constraint with fixed version
+	operator string
+	raw      string
+	ver      Version
+	wildcard int // -1 = no wildcard, 0 - major, 1 - minor, 2 - patch
+}
+
+// match method checks the version.
+func (cct cargoConstraint) match(v Version) bool {
+	return cct.compare(v, cct)
+}
+
+// Match method validates that the version is in constraints.
+//
+// Pre-release versions are matched only if at least one of the constraints
+// has a pre-release on the same 'major.minor.patch' version.
+func (cc CargoConstraints) Match(ver Version) bool {
+	if cargoPrerelease(ver) != "" && !cc.allowsPrerelease(ver) {
+		return false
+	}
+
+	for _, and := range cc.constraints {
+		if !and.match(ver) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsPrerelease checks that the constraints opt-in to the pre-releases of the version.
+func (cc CargoConstraints) allowsPrerelease(ver Version) bool {
+	for _, and := range cc.constraints {
+		if cargoPrerelease(and.ver) != "" && and.ver.Major() == ver.Major() && and.ver.Minor() == ver.Minor() && and.ver.Patch() == ver.Patch() {
+			return true
+		}
+	}
+	return false
+}
+
+// Value method returns original unmodified raw value of the constraints.
+func (cc CargoConstraints) Value() string {
+	return cc.value
+}
+
+// CargoVersion represent Version implementation for Cargo package manager.
+type CargoVersion struct {
+	major, minor, patch int
+	pre                 string
+	value               string
+}
+
+// Value method returns original unmodified raw value of the constraints.
+func (cv CargoVersion) Value() string {
+	return cv.value
+}
+
+// Match method validates that the version is in constraints.
+func (cv CargoVersion) Match(b Constraints) bool {
+	return b.Match(cv)
+}
+
+// Major method returns integer value of the major version segment (e.g. '?.0.0')
+func (cv CargoVersion) Major() int {
+	return cv.major
+}
+
+// Major method returns integer value of the minor version segment (e.g. '0.?.0')
+func (cv CargoVersion) Minor() int {
+	return cv.minor
+}
+
+// Major method returns integer value of the patch version segment (e.g. '0.0.?')
+func (cv CargoVersion) Patch() int {
+	return cv.patch
+}
+
+// Prerelease method returns pre-release part of the version (e.g. 'alpha.1' for '1.0.0-alpha.1')
+func (cv CargoVersion) Prerelease() string {
+	return cv.pre
+}
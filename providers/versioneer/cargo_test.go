@@ -0,0 +1,138 @@
+package versioneer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCargoVersion_Parts(t *testing.T) {
+	raw := "1.2.3-beta.2+build.7"
+	version, err := NewCargoVersion(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Major() != 1 || version.Minor() != 2 || version.Patch() != 3 || version.Value() != raw {
+		t.Errorf("version '%q' parsed incorrectly, got '%+v'", raw, version)
+	}
+	if pre := version.(CargoVersion).Prerelease(); pre != "beta.2" {
+		t.Errorf("unexpected pre-release part %q of version %q", pre, raw)
+	}
+}
+
+func TestCargoVersion_Error(t *testing.T) {
+	for _, raw := range []string{"v1.2.3", "1.2", "1.2.3.4", "hi"} {
+		version, err := NewCargoVersion(raw)
+		if err == nil {
+			t.Errorf("expected error on invalid version %q, got none", raw)
+		}
+		if version != nil {
+			t.Errorf("expected nil version on error, got '%+v'", version)
+		}
+	}
+}
+
+func TestCargoConstraints_Error(t *testing.T) {
+	for _, raw := range []string{">=1.2.3||<=1.4.0", "~>1.2", "1.2.3.4", ""} {
+		constr, err := NewCargoConstraints(raw)
+		if err == nil {
+			t.Errorf("expected error on invalid constraint %q, got none", raw)
+		}
+		if constr != nil {
+			t.Errorf("expected nil constraint on error, got '%+v'", constr)
+		}
+	}
+}
+
+func TestCargoConstraintsAndVersion_MatchMethod(t *testing.T) {
+	// Table test
+	cases := []struct {
+		Constraint string
+		Version    string
+		Result     bool
+	}{
+		// Default (caret) requirements
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.9.0", true},
+		{"1.2.3", "1.2.2", false},
+		{"1.2.3", "2.0.0", false},
+		{"1.2", "1.2.0", true},
+		{"1", "1.99.99", true},
+		{"0.2.3", "0.2.9", true},
+		{"0.2.3", "0.3.0", false},
+		{"0.0.3", "0.0.3", true},
+		{"0.0.3", "0.0.4", false},
+		{"0.0", "0.0.7", true},
+		{"0.0", "0.1.0", false},
+		{"0", "0.9.1", true},
+		{"0", "1.0.0", false},
+		// Caret requirements
+		{"^1.2.3", "1.8.3", true},
+		{"^1.2.3", "2.2.3", false},
+		{"^0.3", "0.3.9", true},
+		{"^0.3", "0.5.0", false},
+		// Tilde requirements
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1.2", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		// Wildcard requirements
+		{"*", "3.0.0", true},
+		{"1.*", "1.7.0", true},
+		{"1.*", "2.0.0", false},
+		{"1.2.*", "1.2.9", true},
+		{"1.2.x", "1.3.0", false},
+		// Comparison requirements
+		{">=1.2.0", "1.2.0", true},
+		{">=1.2", "1.1.9", false},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{">1.2.3", "1.2.4", true},
+		{"<1.2", "1.1.9", true},
+		{"<1.2", "1.2.0", false},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"=1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"=1.2", "1.2.4", true},
+		// Multiple requirements
+		{">=1.2, <1.5", "1.4.9", true},
+		{">=1.2, <1.5", "1.5.0", false},
+		{">= 0.6,< 0.7", "0.6.3", true},
+		// Pre-releases
+		{"1.0.0", "1.0.1-alpha", false},
+		{">=1.0.0-alpha", "1.0.0-alpha.1", true},
+		{">=1.0.0-alpha", "1.0.0-alpha", true},
+		{">=1.0.0-beta", "1.0.0-alpha", false},
+		{">=1.0.0-alpha", "1.0.1-alpha", false},
+		{"^1.0.0-alpha", "1.0.0", true},
+		{"^1.0.0-alpha.2", "1.0.0-alpha.10", true},
+		{"^1.0.0-alpha.beta", "1.0.0-alpha.1", false},
+	}
+
+	for _, tcase := range cases {
+		caseName := fmt.Sprintf("%q->%q)", tcase.Version, tcase.Constraint)
+		t.Run(caseName, func(t *testing.T) {
+			raw := tcase.Constraint
+			constr, err := NewCargoConstraints(raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if constr.Value() != raw {
+				t.Fatalf("unexpected constraint value, expected '%q', got %q", raw, constr.Value())
+			}
+
+			ver, err := NewCargoVersion(tcase.Version)
+			if err != nil {
+				t.Fatalf("unexpected error on version creation: %v", err)
+			}
+			if constr.Match(ver) != tcase.Result {
+				t.Errorf("incorrect constraints(%q)->version(%q) match result, expected '%t', got '%t'", tcase.Constraint, tcase.Version, tcase.Result, !tcase.Result)
+			}
+			if ver.Match(constr) != tcase.Result {
+				t.Errorf("incorrect version(%q)->constraints(%q) match result, expected '%t', got '%t'", tcase.Version, tcase.Constraint, tcase.Result, !tcase.Result)
+			}
+		})
+	}
+}
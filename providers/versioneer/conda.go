@@ -0,0 +1,438 @@
+package versioneer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+Conda versions and constraints semantic parsing implementation.
+
+Versions ordering follows conda's VersionOrder rules: versions are split into components
+on '.' and '_', every component is split into numeric and string parts. Strings are less
+than numbers, 'dev' is less than any other string and 'post' is greater than anything.
+Constraints support '|' (or) and ',' (and) conjunctions, '=1.2' and '1.2.*' fuzzy matches.
+*/
+
+// condaOprFunc represents conda constraint operator check function.
+// It returns true if the version is satisfied by the constraint.
+type condaOprFunc func(v CondaVersion, c condaConstraint) bool
+
+// condaConfig is used to store conda parser configuration.
+type condaConfig struct {
+	operators          map[string]condaOprFunc // List of supported constraints operators mapped to check functions (e.g. '>=')
+	operatorsOrder     []string                // Operators in the matching order (longest first)
+	versionRgxCompiled *regexp.Regexp          // Compiled version regexp
+	prerelease         map[string]struct{}     // Pre-release string parts (e.g. 'rc')
+}
+
+// condaCfg is a global conda parser configuration.
+var condaCfg condaConfig
+
+// Conda parser config initialization and expressions compiling.
+func init() {
+	condaCfg.operators = map[string]condaOprFunc{
+		"":   condaConstraintEqual, // version without operator is an exact one
+		"==": condaConstraintEqual,
+		"!=": condaConstraintNotEqual,
+		">":  condaConstraintGreaterThan,
+		"<":  condaConstraintLessThan,
+		">=": condaConstraintGreaterThanEqual,
+		"<=": condaConstraintLessThanEqual,
+		"~=": condaConstraintCompatible,
+		"=":  condaConstraintFuzzy,
+	}
+	condaCfg.operatorsOrder = []string{"==", "!=", ">=", "<=", "~=", ">", "<", "="}
+	condaCfg.versionRgxCompiled = regexp.MustCompile(`^([0-9]+!)?[0-9a-z_.]+(\+[0-9a-z_.]+)?$`)
+	condaCfg.prerelease = map[string]struct{}{
+		"DEV": {}, "a": {}, "alpha": {}, "b": {}, "beta": {}, "c": {}, "rc": {}, "pre": {}, "preview": {},
+	}
+}
+
+func condaConstraintEqual(v CondaVersion, c condaConstraint) bool {
+	if c.wildcard {
+		return v.startsWith(c.ver)
+	}
+	return v.Compare(c.ver) == 0
+}
+
+func condaConstraintNotEqual(v CondaVersion, c condaConstraint) bool {
+	return !condaConstraintEqual(v, c)
+}
+
+func condaConstraintGreaterThan(v CondaVersion, c condaConstraint) bool {
+	return v.Compare(c.ver) > 0
+}
+
+func condaConstraintLessThan(v CondaVersion, c condaConstraint) bool {
+	return v.Compare(c.ver) < 0
+}
+
+func condaConstraintGreaterThanEqual(v CondaVersion, c condaConstraint) bool {
+	return v.Compare(c.ver) >= 0
+}
+
+func condaConstraintLessThanEqual(v CondaVersion, c condaConstraint) bool {
+	return v.Compare(c.ver) <= 0
+}
+
+// condaConstraintCompatible - '~=1.4.5' is the same as '>=1.4.5,1.4.*'
+func condaConstraintCompatible(v CondaVersion, c condaConstraint) bool {
+	if v.Compare(c.ver) < 0 {
+		return false
+	}
+	if len(c.ver.main) < 2 {
+		return true
+	}
+	prefix := c.ver
+	prefix.main = prefix.main[:len(prefix.main)-1]
+	return v.startsWith(prefix)
+}
+
+// condaConstraintFuzzy - '=1.4' is the same as '1.4.*'
+func condaConstraintFuzzy(v CondaVersion, c condaConstraint) bool {
+	return v.startsWith(c.ver)
+}
+
+// condaPart represents one part of a version component: a number (without leading zeros),
+// a lowercase string, 'DEV' for the 'dev' string or 'post' (greater than anything).
+type condaPart struct {
+	num   string
+	str   string
+	isNum bool
+}
+
+// condaZero is used to fill missing parts and components.
+var condaZero = condaPart{num: "0", isNum: true}
+
+// compare compares the part with another one.
+func (cp condaPart) compare(o condaPart) int {
+	switch true {
+	case cp == o:
+		return 0
+	case cp.str == "post":
+		return 1
+	case o.str == "post":
+		return -1
+	case !cp.isNum && o.isNum:
+		return -1
+	case cp.isNum && !o.isNum:
+		return 1
+	case cp.isNum:
+		// Values are stored without leading zeros, so longer number is always a bigger one.
+		if len(cp.num) != len(o.num) {
+			return compareInts(len(cp.num), len(o.num))
+		}
+		return strings.Compare(cp.num, o.num)
+	}
+	return strings.Compare(cp.str, o.str)
+}
+
+// condaComponent represents one version component (e.g. '1rc1' of '1.1rc1').
+type condaComponent []condaPart
+
+// compare compares the component with another one, missing parts are filled with zeros.
+func (cc condaComponent) compare(o condaComponent) int {
+	for i := 0; i < len(cc) || i < len(o); i++ {
+		l, r := condaZero, condaZero
+		if i < len(cc) {
+			l = cc[i]
+		}
+		if i < len(o) {
+			r = o[i]
+		}
+		if cmp := l.compare(r); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// compareCondaComponents compares components lists, missing components are considered zeros.
+func compareCondaComponents(a, b []condaComponent) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var l, r condaComponent
+		if i < len(a) {
+			l = a[i]
+		}
+		if i < len(b) {
+			r = b[i]
+		}
+		if cmp := l.compare(r); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// parseCondaComponents parses version string (without epoch and local parts) into the components list.
+func parseCondaComponents(value string) []condaComponent {
+	var res []condaComponent
+	for _, raw := range strings.FieldsFunc(value, func(r rune) bool { return r == '.' || r == '_' }) {
+		var comp condaComponent
+		start := 0
+		for i := 1; i <= len(raw); i++ {
+			if i < len(raw) && isDigit(raw[i]) == isDigit(raw[start]) {
+				continue
+			}
+			part := raw[start:i]
+			if isDigit(part[0]) {
+				part = strings.TrimLeft(part, "0")
+				if part == "" {
+					part = "0"
+				}
+				comp = append(comp, condaPart{num: part, isNum: true})
+			} else {
+				if len(comp) == 0 {
+					// '1.1.a1' is the same as '1.1.0a1'
+					comp = append(comp, condaZero)
+				}
+				if part == "dev" {
+					part = "DEV"
+				}
+				comp = append(comp, condaPart{str: part})
+			}
+			start = i
+		}
+		res = append(res, comp)
+	}
+	return res
+}
+
+// isDigit reports whether the byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// NewCondaVersion constructs ready-to-use conda Version instance.
+func NewCondaVersion(value string) (Version, error) {
+	raw := strings.ToLower(strings.TrimSpace(value))
+	if !condaCfg.versionRgxCompiled.MatchString(raw) {
+		return nil, fmt.Errorf("version '%s' is not supported", value)
+	}
+
+	cv := CondaVersion{value: value, epoch: "0"}
+	if idx := strings.Index(raw, "!"); idx != -1 {
+		cv.epoch = strings.TrimLeft(raw[:idx], "0")
+		if cv.epoch == "" {
+			cv.epoch = "0"
+		}
+		raw = raw[idx+1:]
+	}
+	if idx := strings.Index(raw, "+"); idx != -1 {
+		cv.local = parseCondaComponents(raw[idx+1:])
+		raw = raw[:idx]
+	}
+	cv.main = parseCondaComponents(raw)
+	if len(cv.main) == 0 {
+		return nil, fmt.Errorf("version '%s' is not supported", value)
+	}
+
+	// Major, minor and patch are leading numbers of the first components
+	segments := []*int{&cv.major, &cv.minor, &cv.patch}
+	for i := 0; i < len(segments) && i < len(cv.main); i++ {
+		temp, err := strconv.ParseInt(cv.main[i][0].num, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("segment parse error: %s", err)
+		}
+		*segments[i] = int(temp)
+	}
+
+	return cv, nil
+}
+
+// NewCondaConstraints constructs ready-to-use conda Constraints instance.
+//
+// Version specs are split by '|' (or) and ',' (and), e.g. '>=1.2,<2|3.1.*'.
+// An empty spec or '*' matches any version.
+func NewCondaConstraints(value string) (Constraints, error) {
+	// https://docs.conda.io/projects/conda-build/en/latest/resources/package-spec.html#package-match-specifications
+	cc := CondaConstraints{value: value}
+	raw := strings.TrimSpace(value)
+	if raw == "" || raw == "*" {
+		return cc, nil
+	}
+	if strings.ContainsAny(raw, "()") {
+		return nil, fmt.Errorf("constraint not supported: %q", value)
+	}
+
+	for _, orPart := range strings.Split(raw, "|") {
+		var and []condaConstraint
+		for _, andPart := range strings.Split(orPart, ",") {
+			cns, err := parseCondaConstraint(andPart)
+			if err != nil {
+				return nil, fmt.Errorf("constraint not supported: %q: %w", value, err)
+			}
+			if cns != nil {
+				and = append(and, *cns)
+			}
+		}
+		cc.constraints = append(cc.constraints, and)
+	}
+
+	return cc, nil
+}
+
+// parseCondaConstraint is a utility function to convert one raw constraint (e.g. '>=1.2') into condaConstraint.
+// Nil constraint is returned for '*' (any version).
+func parseCondaConstraint(raw string) (*condaConstraint, error) {
+	raw = strings.TrimSpace(raw)
+	cns := &condaConstraint{raw: raw}
+	for _, opr := range condaCfg.operatorsOrder {
+		if strings.HasPrefix(raw, opr) {
+			cns.operator = opr
+			break
+		}
+	}
+	version := strings.TrimSpace(raw[len(cns.operator):])
+	if version == "*" && (cns.operator == "" || cns.operator == "=") {
+		return nil, nil
+	}
+
+	// '1.2.*' and '1.2*' are fuzzy versions
+	if strings.HasSuffix(version, "*") {
+		version = strings.TrimSuffix(strings.TrimSuffix(version, "*"), ".")
+		switch cns.operator {
+		case "", "==", "!=", "=":
+			cns.wildcard = true
+		}
+	}
+	cns.compare = condaCfg.operators[cns.operator]
+
+	ver, err := NewCondaVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	cns.ver = ver.(CondaVersion)
+
+	return cns, nil
+}
+
+// CondaConstraints represent Constraints implementation for conda package manager.
+type CondaConstraints struct {
+	value       string
+	constraints [][]condaConstraint // or->and list, empty list matches any version
+}
+
+// condaConstraint represent one constraint (e.g. for '>=1.2,<2' one of the constraints is '>=1.2')
+type condaConstraint struct {
+	compare  condaOprFunc
+	operator string
+	raw      string
+	ver      CondaVersion
+	wildcard bool
+}
+
+// Match method validates that the version is in constraints.
+func (cc CondaConstraints) Match(ver Version) bool {
+	cv, ok := ver.(CondaVersion)
+	if !ok {
+		parsed, err := NewCondaVersion(ver.Value())
+		if err != nil {
+			return false
+		}
+		cv = parsed.(CondaVersion)
+	}
+	if len(cc.constraints) == 0 {
+		return true
+	}
+
+	for _, and := range cc.constraints {
+		matched := true
+		for _, cns := range and {
+			if !cns.compare(cv, cns) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Value method returns original unmodified raw value of the constraints.
+func (cc CondaConstraints) Value() string {
+	return cc.value
+}
+
+// CondaVersion represent Version implementation for conda package manager.
+type CondaVersion struct {
+	major, minor, patch int
+	epoch               string
+	main, local         []condaComponent
+	value               string
+}
+
+// Compare compares the version with another one using conda ordering rules.
+//
+// It returns 0 if the versions are equal, -1 if cv is less than b and +1 if cv is greater than b.
+func (cv CondaVersion) Compare(b CondaVersion) int {
+	if cv.epoch != b.epoch {
+		if len(cv.epoch) != len(b.epoch) {
+			return compareInts(len(cv.epoch), len(b.epoch))
+		}
+		return strings.Compare(cv.epoch, b.epoch)
+	}
+	if cmp := compareCondaComponents(cv.main, b.main); cmp != 0 {
+		return cmp
+	}
+	return compareCondaComponents(cv.local, b.local)
+}
+
+// startsWith reports whether the version components start with the prefix version components.
+func (cv CondaVersion) startsWith(prefix CondaVersion) bool {
+	if cv.epoch != prefix.epoch {
+		return false
+	}
+	for i, comp := range prefix.main {
+		var own condaComponent
+		if i < len(cv.main) {
+			own = cv.main[i]
+		}
+		if own.compare(comp) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Prerelease reports whether the version has a pre-release part (e.g. '1.0rc1' or '2.0.dev0').
+func (cv CondaVersion) Prerelease() bool {
+	for _, comp := range cv.main {
+		for _, part := range comp {
+			if _, ok := condaCfg.prerelease[part.str]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Value method returns original unmodified raw value of the constraints.
+func (cv CondaVersion) Value() string {
+	return cv.value
+}
+
+// Match method validates that the version is in constraints.
+func (cv CondaVersion) Match(b Constraints) bool {
+	return b.Match(cv)
+}
+
+// Major method returns integer value of the major version segment (e.g. '?.0.0')
+func (cv CondaVersion) Major() int {
+	return cv.major
+}
+
+// Major method returns integer value of the minor version segment (e.g. '0.?.0')
+func (cv CondaVersion) Minor() int {
+	return cv.minor
+}
+
+// Major method returns integer value of the patch version segment (e.g. '0.0.?')
+func (cv CondaVersion) Patch() int {
+	return cv.patch
+}
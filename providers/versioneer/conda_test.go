@@ -0,0 +1,202 @@
+package versioneer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCondaVersion_Parts(t *testing.T) {
+	raw := "1!2.10.3rc1+cuda.11"
+	version, err := NewCondaVersion(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Major() != 2 || version.Minor() != 10 || version.Patch() != 3 || version.Value() != raw {
+		t.Errorf("version '%q' parsed incorrectly, got '%+v'", raw, version)
+	}
+	if !version.(CondaVersion).Prerelease() {
+		t.Errorf("expected version %q to be a pre-release", raw)
+	}
+}
+
+func TestCondaVersion_Error(t *testing.T) {
+	for _, raw := range []string{"", "1.2 3", ">=1.2", "1.2.*", "1.2-3"} {
+		version, err := NewCondaVersion(raw)
+		if err == nil {
+			t.Errorf("expected error on invalid version %q, got none", raw)
+		}
+		if version != nil {
+			t.Errorf("expected nil version on error, got '%+v'", version)
+		}
+	}
+}
+
+func TestCondaVersion_Prerelease(t *testing.T) {
+	cases := map[string]bool{
+		"1.21.2":     false,
+		"1.1.1l":     false,
+		"2021.10":    false,
+		"1.0.post1":  false,
+		"1.0rc1":     true,
+		"2.0.0.dev0": true,
+		"0.5b3":      true,
+	}
+	for raw, expected := range cases {
+		ver, err := NewCondaVersion(raw)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ver.(CondaVersion).Prerelease() != expected {
+			t.Errorf("unexpected pre-release flag for %q, expected %t", raw, expected)
+		}
+	}
+}
+
+func TestCondaVersion_CompareMethod(t *testing.T) {
+	// Every version in the list is less than the next one (conda VersionOrder documentation).
+	ordered := []string{
+		"0.4",
+		"0.4.1.rc",
+		"0.4.1",
+		"0.5a1",
+		"0.5b3",
+		"0.5C1",
+		"0.5",
+		"0.9.6",
+		"0.960923",
+		"1.0",
+		"1.1dev1",
+		"1.1a1",
+		"1.1.0dev1",
+		"1.1.a1",
+		"1.1.0rc1",
+		"1.1.0",
+		"1.1.0post1",
+		"1.1post1",
+		"1996.07.12",
+		"1!0.4.1",
+		"1!3.1.1.6",
+		"2!0.4.1",
+	}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, err := NewCondaVersion(ordered[i])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := NewCondaVersion(ordered[i+1])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cmp := a.(CondaVersion).Compare(b.(CondaVersion)); cmp != -1 {
+			t.Errorf("expected %q < %q, got compare result %d", ordered[i], ordered[i+1], cmp)
+		}
+		if cmp := b.(CondaVersion).Compare(a.(CondaVersion)); cmp != 1 {
+			t.Errorf("expected %q > %q, got compare result %d", ordered[i+1], ordered[i], cmp)
+		}
+	}
+
+	// Every pair of versions is equal.
+	equal := [][2]string{
+		{"0.4", "0.4.0"},
+		{"0.4.1.rc", "0.4.1.RC"},
+		{"1.1.0dev1", "1.1.dev1"},
+		{"1.1", "1.1.0"},
+		{"1.1.0post1", "1.1.post1"},
+		{"1.2_1", "1.2.1"},
+	}
+	for _, pair := range equal {
+		a, _ := NewCondaVersion(pair[0])
+		b, _ := NewCondaVersion(pair[1])
+		if cmp := a.(CondaVersion).Compare(b.(CondaVersion)); cmp != 0 {
+			t.Errorf("expected %q == %q, got compare result %d", pair[0], pair[1], cmp)
+		}
+	}
+}
+
+func TestCondaConstraints_Error(t *testing.T) {
+	for _, raw := range []string{">=1.2,", "(1.2|1.3),<2", ">=>1.2", "1.2 3", "|1.2"} {
+		constr, err := NewCondaConstraints(raw)
+		if err == nil {
+			t.Errorf("expected error on invalid constraint %q, got none", raw)
+		}
+		if constr != nil {
+			t.Errorf("expected nil constraint on error, got '%+v'", constr)
+		}
+	}
+}
+
+func TestCondaConstraintsAndVersion_MatchMethod(t *testing.T) {
+	// Table test
+	cases := []struct {
+		Constraint string
+		Version    string
+		Result     bool
+	}{
+		// Any version
+		{"", "1.0", true},
+		{"*", "1.0", true},
+		// Exact versions
+		{"1.21.2", "1.21.2", true},
+		{"1.21", "1.21.0", true},
+		{"1.21", "1.21.2", false},
+		{"==1.21.2", "1.21.2", true},
+		{"!=1.21.2", "1.21.2", false},
+		{"!=1.21.2", "1.21.3", true},
+		// Fuzzy versions
+		{"=1.21", "1.21.2", true},
+		{"=1.21", "1.21", true},
+		{"=1.21", "1.210", false},
+		{"=1.21", "1.22.0", false},
+		{"1.21.*", "1.21.5", true},
+		{"1.21*", "1.21.5", true},
+		{"1.21.*", "1.22", false},
+		{"!=1.21.*", "1.22", true},
+		{"=3.9", "3.9.7", true},
+		// Comparison operators
+		{">1.20", "1.20.1", true},
+		{">1.20", "1.20", false},
+		{">=1.20", "1.20", true},
+		{"<1.20", "1.20rc1", true},
+		{"<=1.20", "1.20.0", true},
+		{">=1.20.*", "1.20", true},
+		// Compatible release
+		{"~=1.4.5", "1.4.7", true},
+		{"~=1.4.5", "1.4.4", false},
+		{"~=1.4.5", "1.5.0", false},
+		{"~=1.4", "1.9", true},
+		// Conjunctions
+		{">=1.20,<1.22", "1.21.2", true},
+		{">=1.20,<1.22", "1.22.0", false},
+		{"1.19.*|>=1.21", "1.19.5", true},
+		{"1.19.*|>=1.21", "1.20.0", false},
+		{"1.19.*|>=1.21", "1.21.0", true},
+		// Epochs
+		{">=1.0", "1!0.1", true},
+		{"=1!0.1", "1!0.1.2", true},
+	}
+
+	for _, tcase := range cases {
+		caseName := fmt.Sprintf("%q->%q)", tcase.Version, tcase.Constraint)
+		t.Run(caseName, func(t *testing.T) {
+			raw := tcase.Constraint
+			constr, err := NewCondaConstraints(raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if constr.Value() != raw {
+				t.Fatalf("unexpected constraint value, expected '%q', got %q", raw, constr.Value())
+			}
+
+			ver, err := NewCondaVersion(tcase.Version)
+			if err != nil {
+				t.Fatalf("unexpected error on version creation: %v", err)
+			}
+			if constr.Match(ver) != tcase.Result {
+				t.Errorf("incorrect constraints(%q)->version(%q) match result, expected '%t', got '%t'", tcase.Constraint, tcase.Version, tcase.Result, !tcase.Result)
+			}
+			if ver.Match(constr) != tcase.Result {
+				t.Errorf("incorrect version(%q)->constraints(%q) match result, expected '%t', got '%t'", tcase.Version, tcase.Constraint, tcase.Result, !tcase.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,474 @@
+package versioneer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+Maven versions and constraints semantic parsing implementation.
+
+Versions ordering follows Maven's ComparableVersion rules: versions are split into
+numeric and qualifier items on '.', '-' and digit/letter transitions, and well known
+qualifiers are ordered as 'alpha' < 'beta' < 'milestone' < 'rc' < 'snapshot' < release < 'sp'.
+Unknown qualifiers are considered newer than releases and are compared lexically.
+*/
+
+// mavenQualifiers is an ordered list of well known maven qualifiers (empty string is a release).
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenQualifierAliases maps qualifiers aliases to their canonical form.
+var mavenQualifierAliases = map[string]string{"ga": "", "final": "", "release": "", "cr": "rc"}
+
+// mavenReleaseIndex is a comparable index of the release qualifier.
+var mavenReleaseIndex = strconv.Itoa(indexOf(mavenQualifiers, ""))
+
+// mavenItem represents one parsed version item: integer, qualifier string or a sub-list.
+type mavenItem interface {
+	// compare compares the item with another one, nil other item means "missing item".
+	compare(other mavenItem) int
+	// isNull reports whether the item is equal to the missing one (e.g. '0' or 'ga').
+	isNull() bool
+}
+
+// mavenIntItem represents numeric version item.
+type mavenIntItem string
+
+func (it mavenIntItem) isNull() bool {
+	return it == "0"
+}
+
+func (it mavenIntItem) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if it.isNull() {
+			return 0
+		}
+		return 1
+	case mavenIntItem:
+		// Values are stored without leading zeros, so longer number is always a bigger one.
+		if len(it) != len(o) {
+			return compareInts(len(it), len(o))
+		}
+		return strings.Compare(string(it), string(o))
+	case mavenStringItem:
+		return 1 // 1.1 > 1-sp
+	}
+	return 1 // 1.1 > 1-1
+}
+
+// mavenStringItem represents qualifier version item.
+type mavenStringItem string
+
+func (it mavenStringItem) isNull() bool {
+	return it.comparable() == mavenReleaseIndex
+}
+
+// comparable returns qualifier representation used for lexical ordering.
+func (it mavenStringItem) comparable() string {
+	if i := indexOf(mavenQualifiers, string(it)); i != -1 {
+		return strconv.Itoa(i)
+	}
+	// Unknown qualifiers are considered after known ones, ordered lexically
+	return fmt.Sprintf("%d-%s", len(mavenQualifiers), string(it))
+}
+
+func (it mavenStringItem) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		return strings.Compare(it.comparable(), mavenReleaseIndex) // 1-rc < 1, 1-sp > 1
+	case mavenIntItem:
+		return -1 // 1.any < 1.1
+	case mavenStringItem:
+		return strings.Compare(it.comparable(), o.comparable())
+	}
+	return -1 // 1.any < 1-1
+}
+
+// mavenListItem represents sub-list of version items (started by '-' or digit/letter transition).
+type mavenListItem []mavenItem
+
+func (it mavenListItem) isNull() bool {
+	return len(it) == 0
+}
+
+func (it mavenListItem) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if len(it) == 0 {
+			return 0
+		}
+		return it[0].compare(nil)
+	case mavenIntItem:
+		return -1 // 1-1 < 1.0.x
+	case mavenStringItem:
+		return 1 // 1-1 > 1-sp
+	case mavenListItem:
+		for i := 0; i < len(it) || i < len(o); i++ {
+			var l, r mavenItem
+			if i < len(it) {
+				l = it[i]
+			}
+			if i < len(o) {
+				r = o[i]
+			}
+			var result int
+			if l == nil {
+				if r != nil {
+					result = -r.compare(l)
+				}
+			} else {
+				result = l.compare(r)
+			}
+			if result != 0 {
+				return result
+			}
+		}
+	}
+	return 0
+}
+
+// normalize removes trailing null items (e.g. '1.0.0' is the same as '1').
+func (it mavenListItem) normalize() mavenListItem {
+	for i := len(it) - 1; i >= 0; i-- {
+		if it[i].isNull() {
+			it = append(it[:i], it[i+1:]...)
+		} else if _, ok := it[i].(mavenListItem); !ok {
+			break
+		}
+	}
+	return it
+}
+
+// parseMavenItems parses version string into the maven items list.
+func parseMavenItems(value string) mavenListItem {
+	version := strings.ToLower(value)
+
+	// Sub-lists are stored as pointers while parsing and are converted after it
+	type list struct {
+		items  []mavenItem
+		parent *list
+		index  int // position of the sub-list in the parent
+	}
+	root := &list{}
+	current := root
+	lists := []*list{root}
+
+	newItem := func(raw string, followedByDigit bool) mavenItem {
+		if raw != "" && strings.IndexFunc(raw, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+			raw = strings.TrimLeft(raw, "0")
+			if raw == "" {
+				raw = "0"
+			}
+			return mavenIntItem(raw)
+		}
+		if followedByDigit && len(raw) == 1 {
+			// 'a1' == 'alpha-1', 'b1' == 'beta-1', 'm1' == 'milestone-1'
+			switch raw {
+			case "a":
+				raw = "alpha"
+			case "b":
+				raw = "beta"
+			case "m":
+				raw = "milestone"
+			}
+		}
+		if alias, ok := mavenQualifierAliases[raw]; ok {
+			raw = alias
+		}
+		return mavenStringItem(raw)
+	}
+	newList := func() {
+		sub := &list{parent: current, index: len(current.items)}
+		current.items = append(current.items, nil)
+		lists = append(lists, sub)
+		current = sub
+	}
+
+	isDigit := false
+	start := 0
+	for i, c := range version {
+		switch true {
+		case c == '.' || c == '-':
+			if i == start {
+				current.items = append(current.items, mavenIntItem("0"))
+			} else {
+				current.items = append(current.items, newItem(version[start:i], false))
+			}
+			start = i + 1
+			if c == '-' {
+				newList()
+			}
+		case unicode.IsDigit(c):
+			if !isDigit && i > start {
+				current.items = append(current.items, newItem(version[start:i], true))
+				start = i
+				newList()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				current.items = append(current.items, newItem(version[start:i], false))
+				start = i
+				newList()
+			}
+			isDigit = false
+		}
+	}
+	if len(version) > start {
+		current.items = append(current.items, newItem(version[start:], false))
+	}
+
+	// Normalize sub-lists from the deepest one and put them into their parents
+	for i := len(lists) - 1; i > 0; i-- {
+		l := lists[i]
+		l.parent.items[l.index] = mavenListItem(l.items).normalize()
+	}
+	return mavenListItem(root.items).normalize()
+}
+
+// indexOf returns index of the value in the slice or -1 if it is missing.
+func indexOf(slice []string, value string) int {
+	for i, v := range slice {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewMavenVersion constructs ready-to-use maven Version instance.
+func NewMavenVersion(value string) (Version, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, "[](),") || strings.Contains(value, "${") {
+		return nil, fmt.Errorf("version '%s' is not supported", value)
+	}
+
+	mv := MavenVersion{value: value, items: parseMavenItems(value)}
+	// Major, minor and patch are leading numeric items (if any)
+	segments := []*int{&mv.major, &mv.minor, &mv.patch}
+	for i := 0; i < len(segments) && i < len(mv.items); i++ {
+		num, ok := mv.items[i].(mavenIntItem)
+		if !ok {
+			break
+		}
+		temp, err := strconv.ParseInt(string(num), 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("segment parse error: %s", err)
+		}
+		*segments[i] = int(temp)
+	}
+
+	return mv, nil
+}
+
+// NewMavenConstraints constructs ready-to-use maven Constraints instance.
+//
+// Both version ranges (e.g. '[1.0,2.0)', '(,1.0],[1.2,)' or '[1.5]') and soft requirements
+// (e.g. '1.5') are supported, soft requirements are matched as an exact version.
+func NewMavenConstraints(value string) (Constraints, error) {
+	// https://maven.apache.org/enforcer/enforcer-rules/versionRanges.html
+	raw := strings.TrimSpace(value)
+	if raw == "" {
+		return nil, fmt.Errorf("constraint not supported: %q", value)
+	}
+
+	if !strings.ContainsAny(raw, "[(") {
+		ver, err := NewMavenVersion(raw)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse version: %w", err)
+		}
+		mv := ver.(MavenVersion)
+		return MavenConstraints{value: value, ranges: []mavenRange{{lower: &mv, upper: &mv, lowerInclusive: true, upperInclusive: true}}}, nil
+	}
+
+	var ranges []mavenRange
+	for raw != "" {
+		end := strings.IndexAny(raw, "])")
+		if (raw[0] != '[' && raw[0] != '(') || end == -1 {
+			return nil, fmt.Errorf("constraint not supported: %q", value)
+		}
+		rng, err := parseMavenRange(raw[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("constraint not supported: %q: %w", value, err)
+		}
+		ranges = append(ranges, *rng)
+
+		raw = strings.TrimSpace(raw[end+1:])
+		if strings.HasPrefix(raw, ",") {
+			raw = strings.TrimSpace(raw[1:])
+			if raw == "" {
+				return nil, fmt.Errorf("constraint not supported: %q", value)
+			}
+		} else if raw != "" {
+			return nil, fmt.Errorf("constraint not supported: %q", value)
+		}
+	}
+
+	return MavenConstraints{value: value, ranges: ranges}, nil
+}
+
+// parseMavenRange is a utility function to convert one raw range (e.g. '[1.0,2.0)') into mavenRange.
+func parseMavenRange(raw string) (*mavenRange, error) {
+	rng := &mavenRange{
+		lowerInclusive: raw[0] == '[',
+		upperInclusive: raw[len(raw)-1] == ']',
+	}
+	bounds := strings.Split(raw[1:len(raw)-1], ",")
+
+	parseBound := func(b string) (*MavenVersion, error) {
+		b = strings.TrimSpace(b)
+		if b == "" {
+			return nil, nil
+		}
+		ver, err := NewMavenVersion(b)
+		if err != nil {
+			return nil, err
+		}
+		mv := ver.(MavenVersion)
+		return &mv, nil
+	}
+
+	var err error
+	switch len(bounds) {
+	case 1:
+		// '[1.0]' is an exact version
+		if !rng.lowerInclusive || !rng.upperInclusive {
+			return nil, fmt.Errorf("single version range %q must be inclusive", raw)
+		}
+		if rng.lower, err = parseBound(bounds[0]); err != nil || rng.lower == nil {
+			return nil, fmt.Errorf("invalid range %q", raw)
+		}
+		rng.upper = rng.lower
+	case 2:
+		if rng.lower, err = parseBound(bounds[0]); err != nil {
+			return nil, err
+		}
+		if rng.upper, err = parseBound(bounds[1]); err != nil {
+			return nil, err
+		}
+		if rng.lower != nil && rng.upper != nil && rng.lower.Compare(*rng.upper) > 0 {
+			return nil, fmt.Errorf("range %q lower bound is greater than the upper one", raw)
+		}
+	default:
+		return nil, fmt.Errorf("invalid range %q", raw)
+	}
+
+	return rng, nil
+}
+
+// MavenConstraints represent Constraints implementation for Maven package manager.
+type MavenConstraints struct {
+	value  string
+	ranges []mavenRange
+}
+
+// mavenRange represent one version range (e.g. for '(,1.0],[1.2,)' one of the ranges is '[1.2,)')
+type mavenRange struct {
+	lower, upper                   *MavenVersion // nil bound means no limit
+	lowerInclusive, upperInclusive bool
+}
+
+// match method checks the version.
+func (mr mavenRange) match(v MavenVersion) bool {
+	if mr.lower != nil {
+		cmp := v.Compare(*mr.lower)
+		if cmp < 0 || (cmp == 0 && !mr.lowerInclusive) {
+			return false
+		}
+	}
+	if mr.upper != nil {
+		cmp := v.Compare(*mr.upper)
+		if cmp > 0 || (cmp == 0 && !mr.upperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match method validates that the version is in constraints.
+func (mc MavenConstraints) Match(ver Version) bool {
+	mv, ok := ver.(MavenVersion)
+	if !ok {
+		parsed, err := NewMavenVersion(ver.Value())
+		if err != nil {
+			return false
+		}
+		mv = parsed.(MavenVersion)
+	}
+
+	for _, rng := range mc.ranges {
+		if rng.match(mv) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value method returns original unmodified raw value of the constraints.
+func (mc MavenConstraints) Value() string {
+	return mc.value
+}
+
+// MavenVersion represent Version implementation for Maven package manager.
+type MavenVersion struct {
+	major, minor, patch int
+	items               mavenListItem
+	value               string
+}
+
+// Compare compares the version with another one using maven ordering rules.
+//
+// It returns 0 if the versions are equal, -1 if mv is less than b and +1 if mv is greater than b.
+func (mv MavenVersion) Compare(b MavenVersion) int {
+	return mv.items.compare(b.items)
+}
+
+// Prerelease reports whether the version has a pre-release qualifier
+// (e.g. '1.0-alpha-1', '2.0.0-RC1' or '1.0-SNAPSHOT').
+func (mv MavenVersion) Prerelease() bool {
+	var walk func(items mavenListItem) bool
+	walk = func(items mavenListItem) bool {
+		for _, item := range items {
+			switch it := item.(type) {
+			case mavenStringItem:
+				if it.compare(nil) < 0 {
+					return true
+				}
+			case mavenListItem:
+				if walk(it) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return walk(mv.items)
+}
+
+// Value method returns original unmodified raw value of the constraints.
+func (mv MavenVersion) Value() string {
+	return mv.value
+}
+
+// Match method validates that the version is in constraints.
+func (mv MavenVersion) Match(b Constraints) bool {
+	return b.Match(mv)
+}
+
+// Major method returns integer value of the major version segment (e.g. '?.0.0')
+func (mv MavenVersion) Major() int {
+	return mv.major
+}
+
+// Major method returns integer value of the minor version segment (e.g. '0.?.0')
+func (mv MavenVersion) Minor() int {
+	return mv.minor
+}
+
+// Major method returns integer value of the patch version segment (e.g. '0.0.?')
+func (mv MavenVersion) Patch() int {
+	return mv.patch
+}
@@ -0,0 +1,189 @@
+package versioneer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMavenVersion_Parts(t *testing.T) {
+	raw := "3.12.0-RC1"
+	version, err := NewMavenVersion(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Major() != 3 || version.Minor() != 12 || version.Patch() != 0 || version.Value() != raw {
+		t.Errorf("version '%q' parsed incorrectly, got '%+v'", raw, version)
+	}
+	if !version.(MavenVersion).Prerelease() {
+		t.Errorf("expected version %q to be a pre-release", raw)
+	}
+}
+
+func TestMavenVersion_Error(t *testing.T) {
+	for _, raw := range []string{"", "[1.0]", "${project.version}"} {
+		version, err := NewMavenVersion(raw)
+		if err == nil {
+			t.Errorf("expected error on invalid version %q, got none", raw)
+		}
+		if version != nil {
+			t.Errorf("expected nil version on error, got '%+v'", version)
+		}
+	}
+}
+
+func TestMavenVersion_Prerelease(t *testing.T) {
+	cases := map[string]bool{
+		"1.0":           false,
+		"1.0-SNAPSHOT":  true,
+		"1.0-alpha-1":   true,
+		"2.0.0.M3":      true,
+		"31.0.1-jre":    false,
+		"5.3.9.RELEASE": false,
+		"1.0-sp1":       false,
+	}
+	for raw, expected := range cases {
+		ver, err := NewMavenVersion(raw)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ver.(MavenVersion).Prerelease() != expected {
+			t.Errorf("unexpected pre-release flag for %q, expected %t", raw, expected)
+		}
+	}
+}
+
+func TestMavenVersion_CompareMethod(t *testing.T) {
+	// Every version in the list is less than the next one.
+	ordered := []string{
+		"1-alpha-snapshot",
+		"1-alpha",
+		"1-alpha-1",
+		"1-alpha2",
+		"1-beta",
+		"1-beta-2",
+		"1-milestone-1",
+		"1-rc",
+		"1-rc2",
+		"1-snapshot",
+		"1",
+		"1-sp",
+		"1-abc",
+		"1-xyz",
+		"1-1",
+		"1.0.1",
+		"1.1-alpha",
+		"1.1",
+		"1.2",
+		"1.10",
+		"2.0.0.M1",
+		"2.0.0",
+		"2.0.0-jre",
+		"10",
+	}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, err := NewMavenVersion(ordered[i])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := NewMavenVersion(ordered[i+1])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cmp := a.(MavenVersion).Compare(b.(MavenVersion)); cmp != -1 {
+			t.Errorf("expected %q < %q, got compare result %d", ordered[i], ordered[i+1], cmp)
+		}
+		if cmp := b.(MavenVersion).Compare(a.(MavenVersion)); cmp != 1 {
+			t.Errorf("expected %q > %q, got compare result %d", ordered[i+1], ordered[i], cmp)
+		}
+	}
+
+	// Every pair of versions is equal.
+	equal := [][2]string{
+		{"1", "1.0.0"},
+		{"1-ga", "1"},
+		{"1.final", "1"},
+		{"1-cr1", "1-rc1"},
+		{"1a1", "1-alpha-1"},
+		{"1.0.0-RELEASE", "1"},
+		{"01.002", "1.2"},
+	}
+	for _, pair := range equal {
+		a, _ := NewMavenVersion(pair[0])
+		b, _ := NewMavenVersion(pair[1])
+		if cmp := a.(MavenVersion).Compare(b.(MavenVersion)); cmp != 0 {
+			t.Errorf("expected %q == %q, got compare result %d", pair[0], pair[1], cmp)
+		}
+	}
+}
+
+func TestMavenConstraints_Error(t *testing.T) {
+	for _, raw := range []string{"", "[1.0", "1.0]", "[1.0,2.0),", "[2.0,1.0]", "(1.0)", "[1.0,2.0,3.0]", "[1.0] 2.0"} {
+		constr, err := NewMavenConstraints(raw)
+		if err == nil {
+			t.Errorf("expected error on invalid constraint %q, got none", raw)
+		}
+		if constr != nil {
+			t.Errorf("expected nil constraint on error, got '%+v'", constr)
+		}
+	}
+}
+
+func TestMavenConstraintsAndVersion_MatchMethod(t *testing.T) {
+	// Table test
+	cases := []struct {
+		Constraint string
+		Version    string
+		Result     bool
+	}{
+		// Soft requirements
+		{"1.0", "1.0", true},
+		{"1.0", "1.0.0", true},
+		{"1.0", "1.0.1", false},
+		// Exact ranges
+		{"[1.0]", "1.0", true},
+		{"[1.0]", "1.1", false},
+		// Ranges
+		{"[1.0,2.0)", "1.0", true},
+		{"[1.0,2.0)", "1.9.9", true},
+		{"[1.0,2.0)", "2.0", false},
+		{"[1.0,2.0)", "2.0-alpha-1", true},
+		{"(1.0,2.0]", "1.0", false},
+		{"(1.0,2.0]", "2.0", true},
+		{"[1.5,)", "1.4", false},
+		{"[1.5,)", "99.0", true},
+		{"(,1.0]", "0.1", true},
+		{"(,1.0]", "1.0.1", false},
+		{"[ 1.0 , 2.0 )", "1.5", true},
+		// Multiple ranges
+		{"(,1.0],[1.2,)", "1.1", false},
+		{"(,1.0],[1.2,)", "1.0", true},
+		{"(,1.0],[1.2,)", "1.3", true},
+		{"(,1.1),(1.1,)", "1.1", false},
+		{"(,1.1),(1.1,)", "1.1.1", true},
+	}
+
+	for _, tcase := range cases {
+		caseName := fmt.Sprintf("%q->%q)", tcase.Version, tcase.Constraint)
+		t.Run(caseName, func(t *testing.T) {
+			raw := tcase.Constraint
+			constr, err := NewMavenConstraints(raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if constr.Value() != raw {
+				t.Fatalf("unexpected constraint value, expected '%q', got %q", raw, constr.Value())
+			}
+
+			ver, err := NewMavenVersion(tcase.Version)
+			if err != nil {
+				t.Fatalf("unexpected error on version creation: %v", err)
+			}
+			if constr.Match(ver) != tcase.Result {
+				t.Errorf("incorrect constraints(%q)->version(%q) match result, expected '%t', got '%t'", tcase.Constraint, tcase.Version, tcase.Result, !tcase.Result)
+			}
+			if ver.Match(constr) != tcase.Result {
+				t.Errorf("incorrect version(%q)->constraints(%q) match result, expected '%t', got '%t'", tcase.Version, tcase.Constraint, tcase.Result, !tcase.Result)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ type pipConfig struct {
 	wildcardRgx            string                // pip wildcard version regexp (e.g. v1.2.*)
 	constraintsRgxCompiled *regexp.Regexp        // Compiled pip constraint+wildcard regexp
 	versionRgxCompiled     *regexp.Regexp        // Compiled version regexp
+	pep440RgxCompiled      *regexp.Regexp        // Compiled PEP 440 version regexp
 }
 
 // pipCfg is a global pip parser configuration.
@@ -30,7 +31,8 @@ var pipCfg pipConfig
 // pip parser config initialization and expressions compiling.
 func init() {
 	pipCfg.versionRgx = `v?([0-9]+)(\.[0-9]+)?(\.[0-9]+)?(\.[0-9]+)?(-([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?(\+([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?`
-	pipCfg.wildcardRgx = `v?([0-9|x|X|\*]+)(\.[0-9|x|X|\*]+)?(\.[0-9|x|X|\*]+)?(-([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?(\+([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?`
+	pipCfg.wildcardRgx = `v?(?:[0-9]+!)?([0-9|x|X|\*]+)(\.[0-9|x|X|\*]+)?(\.[0-9|x|X|\*]+)?(?:\.[0-9]+)*` +
+		`((?:[-_.]?(?:a|b|c|rc|alpha|beta|pre|preview|post|rev|r|dev)[-_.]?[0-9]*)+|-[0-9A-Za-z\-]+(?:\.[0-9A-Za-z\-]+)*)?(\+([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?`
 	// Supported pip constraints operators
 	pipCfg.operators = map[string]pipOprFunc{
 		"":    pipConstraintEqual,
@@ -49,20 +51,26 @@ func init() {
 	for k := range pipCfg.operators {
 		ops = append(ops, regexp.QuoteMeta(k))
 	}
-	pipCfg.constraintsRgxCompiled = regexp.MustCompile(fmt.Sprintf(`^\s*(%s)\s*(%s)\s*$`, strings.Join(ops, "|"), pipCfg.wildcardRgx))
+	pipCfg.constraintsRgxCompiled = regexp.MustCompile(fmt.Sprintf(`(?i)^\s*(%s)\s*(%s)\s*$`, strings.Join(ops, "|"), pipCfg.wildcardRgx))
 	pipCfg.versionRgxCompiled = regexp.MustCompile("^" + pipCfg.versionRgx + "$")
+	// PEP 440 groups: 1 - epoch, 2 - release, 3/4 - pre-release, 5/6/7 - post-release, 8/9 - development release
+	pipCfg.pep440RgxCompiled = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+		`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d*))?` +
+		`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d*))?` +
+		`(?:[-_.]?(dev)[-_.]?(\d*))?(?:\+[a-z0-9.\-_]*)?$`)
 }
 
 func pipConstraintEqual(v Version, c pipConstraint) bool {
+	pv, cv := toPipVersion(v), toPipVersion(c.ver)
 	switch c.wildcard {
 	case wildcardNone:
-		return v.Major() == c.ver.Major() && v.Minor() == c.ver.Minor() && v.Patch() == c.ver.Patch() // fully equal
+		return pv.Compare(cv) == 0 // fully equal (local version label is ignored)
 	case wildcardMajor:
 		return true // * is always equal to any version
 	case wildcardMinor:
-		return v.Major() == c.ver.Major() // major equal
+		return pv.epoch == cv.epoch && v.Major() == c.ver.Major() // major equal
 	case wildcardPatch:
-		return v.Major() == c.ver.Major() && v.Minor() == c.ver.Minor() // major equal, minor equal
+		return pv.epoch == cv.epoch && v.Major() == c.ver.Major() && v.Minor() == c.ver.Minor() // major equal, minor equal
 	}
 	return false
 }
@@ -80,29 +88,12 @@ func pipConstraintNotEqual(v Version, c pipConstraint) bool {
 
 func pipConstraintGreaterThan(v Version, c pipConstraint) bool {
 	// No wildcard needed
-	switch true {
-	case v.Major() != c.ver.Major():
-		return v.Major() > c.ver.Major()
-	case v.Minor() != c.ver.Minor():
-		return v.Minor() > c.ver.Minor()
-	case v.Patch() != c.ver.Patch():
-		return v.Patch() > c.ver.Patch()
-	}
-	return false
+	return toPipVersion(v).Compare(toPipVersion(c.ver)) > 0
 }
 
 func pipConstraintLessThan(v Version, c pipConstraint) bool {
 	// No wildcard needed
-	switch true {
-	case v.Major() != c.ver.Major():
-		return v.Major() < c.ver.Major()
-	case v.Minor() != c.ver.Minor():
-		return v.Minor() < c.ver.Minor()
-	case v.Patch() != c.ver.Patch():
-		return v.Patch() < c.ver.Patch()
-	}
-
-	return false
+	return toPipVersion(v).Compare(toPipVersion(c.ver)) < 0
 }
 
 func pipConstraintGreaterThanEqual(v Version, c pipConstraint) bool {
@@ -134,8 +125,15 @@ func pipConstraintTildeEqual(v Version, c pipConstraint) bool {
 }
 
 // NewPipVersion constructs ready-to-use Pip Version instance.
+//
+// PEP 440 versions (epoch, pre, post and development releases) are supported, other versions
+// are parsed as 'major.minor.patch' releases (e.g. legacy 'v1.2.3-hello').
 func NewPipVersion(value string) (Version, error) {
-	nval := strings.ToLower(value)
+	nval := strings.ToLower(strings.TrimSpace(value))
+	if matches := pipCfg.pep440RgxCompiled.FindStringSubmatch(nval); matches != nil {
+		return newPEP440Version(value, matches)
+	}
+
 	matches := pipCfg.versionRgxCompiled.FindStringSubmatch(nval)
 	if matches == nil {
 		return nil, fmt.Errorf("version '%s' is not supported", value)
@@ -146,7 +144,7 @@ func NewPipVersion(value string) (Version, error) {
 	if temp, err = strconv.ParseInt(matches[1], 10, 0); err != nil {
 		return nil, fmt.Errorf("segment parse error: %s", err)
 	}
-	sv := PipVersion{value: value}
+	sv := PipVersion{value: value, prePhase: pipFinal, post: -1, dev: pipNoDev}
 	sv.major = int(temp)
 	if matches[2] != "" {
 		if temp, err = strconv.ParseInt(strings.TrimPrefix(matches[2], "."), 10, 0); err != nil {
@@ -160,10 +158,84 @@ func NewPipVersion(value string) (Version, error) {
 		}
 		sv.patch = int(temp)
 	}
+	sv.release = trimZeros([]int{sv.major, sv.minor, sv.patch})
 
 	return sv, nil
 }
 
+// Pre-release phases of PipVersion, development-only releases are lower than any pre-release.
+const (
+	pipDevOnly = iota - 1
+	pipAlpha
+	pipBeta
+	pipRC
+	pipFinal
+)
+
+// pipNoDev is the development release number of PipVersion without development segment.
+const pipNoDev = int(^uint(0) >> 1)
+
+// newPEP440Version - helper to construct PipVersion from PEP 440 regexp matches.
+func newPEP440Version(value string, matches []string) (Version, error) {
+	sv := PipVersion{value: value, prePhase: pipFinal, post: -1, dev: pipNoDev}
+	var err error
+	if matches[1] != "" {
+		if sv.epoch, err = strconv.Atoi(matches[1]); err != nil {
+			return nil, fmt.Errorf("segment parse error: %s", err)
+		}
+	}
+	for _, part := range strings.Split(matches[2], ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("segment parse error: %s", err)
+		}
+		sv.release = append(sv.release, n)
+	}
+	parts := append(append([]int{}, sv.release...), 0, 0)
+	sv.major, sv.minor, sv.patch = parts[0], parts[1], parts[2]
+	sv.release = trimZeros(sv.release)
+
+	switch matches[3] {
+	case "a", "alpha":
+		sv.prePhase = pipAlpha
+	case "b", "beta":
+		sv.prePhase = pipBeta
+	case "c", "rc", "pre", "preview":
+		sv.prePhase = pipRC
+	}
+	// Implicit numbers are zeros (e.g. '1.0rc' is '1.0rc0')
+	sv.pre, _ = strconv.Atoi(matches[4])
+	if matches[5] != "" || matches[6] != "" {
+		sv.post, _ = strconv.Atoi(matches[5] + matches[7])
+	}
+	if matches[8] != "" {
+		sv.dev, _ = strconv.Atoi(matches[9])
+		if matches[3] == "" && sv.post < 0 {
+			sv.prePhase = pipDevOnly
+		}
+	}
+	return sv, nil
+}
+
+// trimZeros - helper to trim release trailing zeros ('1.0.0' is equal to '1').
+func trimZeros(release []int) []int {
+	for len(release) > 1 && release[len(release)-1] == 0 {
+		release = release[:len(release)-1]
+	}
+	return release
+}
+
+// toPipVersion - helper to convert any Version to PipVersion ('major.minor.patch' release is used for other implementations).
+func toPipVersion(v Version) PipVersion {
+	if pv, ok := v.(PipVersion); ok {
+		return pv
+	}
+	return PipVersion{
+		major: v.Major(), minor: v.Minor(), patch: v.Patch(), value: v.Value(),
+		release: trimZeros([]int{v.Major(), v.Minor(), v.Patch()}), prePhase: pipFinal, post: -1, dev: pipNoDev,
+	}
+}
+
 // NewPipConstraints constructs ready-to-use pip Constraints instance.
 func NewPipConstraints(value string) (Constraints, error) {
 	// https://www.python.org/dev/peps/pep-0440/#version-specifiers
@@ -243,7 +315,13 @@ func (cct pipConstraint) match(v Version) bool {
 }
 
 // Match method validates that the version is in constraints.
+//
+// Pre-release versions are matched only if at least one of the constraints has a pre-release version (PEP 440).
 func (cc PipConstraints) Match(ver Version) bool {
+	if toPipVersion(ver).Prerelease() && !cc.allowsPrerelease() {
+		return false
+	}
+
 	for _, and := range cc.constraints {
 		if !and.match(ver) {
 			return false
@@ -252,6 +330,16 @@ func (cc PipConstraints) Match(ver Version) bool {
 	return true
 }
 
+// allowsPrerelease checks that the constraints opt-in to the pre-releases.
+func (cc PipConstraints) allowsPrerelease() bool {
+	for _, and := range cc.constraints {
+		if toPipVersion(and.ver).Prerelease() {
+			return true
+		}
+	}
+	return false
+}
+
 // Value method returns original unmodified raw value of the constraints.
 func (cc PipConstraints) Value() string {
 	return cc.value
@@ -261,6 +349,12 @@ func (cc PipConstraints) Value() string {
 type PipVersion struct {
 	major, minor, patch int
 	value               string
+	// epoch and release (without trailing zeros) are PEP 440 version segments
+	epoch   int
+	release []int
+	// prePhase and pre - pre-release phase (pipFinal if there is no pre-release) and number,
+	// post - post-release number (-1 if there is none), dev - development release number (pipNoDev if there is none)
+	prePhase, pre, post, dev int
 }
 
 // Value method returns original unmodified raw value of the constraints.
@@ -287,3 +381,58 @@ func (cv PipVersion) Minor() int {
 func (cv PipVersion) Patch() int {
 	return cv.patch
 }
+
+// Prerelease method returns true for pre-release and development versions (e.g. '1.0rc1' or '1.0.dev2')
+func (cv PipVersion) Prerelease() bool {
+	return cv.prePhase != pipFinal || cv.dev != pipNoDev
+}
+
+// Compare method compares PEP 440 versions, it returns -1, 0 or +1 depending on the versions order.
+//
+// Local version labels are ignored ('1.0+local' is equal to '1.0').
+func (cv PipVersion) Compare(b PipVersion) int {
+	if c := compareInts(cv.epoch, b.epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(cv.release) || i < len(b.release); i++ {
+		var x, y int
+		if i < len(cv.release) {
+			x = cv.release[i]
+		}
+		if i < len(b.release) {
+			y = b.release[i]
+		}
+		if c := compareInts(x, y); c != 0 {
+			return c
+		}
+	}
+	for _, c := range []int{
+		compareInts(cv.prePhase, b.prePhase), compareInts(cv.pre, b.pre),
+		compareInts(cv.post, b.post), compareInts(cv.dev, b.dev),
+	} {
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// PipPackageName represents canonical (PEP 503 normalized) python package name, e.g. 'python-dateutil'
+// for 'Python_DateUtil'. Names spelled differently are the same package if their canonical names are equal.
+//
+// Canonical names are used for lookups and comparisons only, keep the original spelling for display.
+type PipPackageName string
+
+// pipNameSeparatorsRgx matches runs of the package name separators.
+var pipNameSeparatorsRgx = regexp.MustCompile(`[-_.]+`)
+
+// NormalizePipName returns canonical python package name (PEP 503): runs of '-', '_' and '.' are replaced
+// with a single '-' and the name is lowercased.
+func NormalizePipName(name string) PipPackageName {
+	return PipPackageName(strings.ToLower(pipNameSeparatorsRgx.ReplaceAllString(strings.TrimSpace(name), "-")))
+}
+
+// String returns canonical package name.
+func (pn PipPackageName) String() string {
+	return string(pn)
+}
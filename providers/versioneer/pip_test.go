@@ -26,6 +26,63 @@ func TestPipVersion_Error(t *testing.T) {
 	}
 }
 
+func TestPipVersion_CompareMethod(t *testing.T) {
+	// Every version in the list is less than the next one.
+	ordered := []string{
+		"1.0.dev1", "1.0a1.dev1", "1.0a1", "1.0a2", "1.0b1", "1.0rc1", "1.0",
+		"1.0.post1.dev1", "1.0.post1", "1.0.1", "1.1", "1.10", "2!0.1",
+	}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, err := NewPipVersion(ordered[i])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := NewPipVersion(ordered[i+1])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cmp := a.(PipVersion).Compare(b.(PipVersion)); cmp != -1 {
+			t.Errorf("expected %q < %q, got compare result %d", ordered[i], ordered[i+1], cmp)
+		}
+		if cmp := b.(PipVersion).Compare(a.(PipVersion)); cmp != 1 {
+			t.Errorf("expected %q > %q, got compare result %d", ordered[i+1], ordered[i], cmp)
+		}
+	}
+
+	// Every pair of versions is equal.
+	equal := [][2]string{
+		{"1.0", "1.0.0"},
+		{"v1.0+local", "1.0"},
+		{"1.0-1", "1.0.post1"},
+		{"1.0RC1", "1.0c1"},
+		{"1.0-alpha.2", "1.0a2"},
+		{"1.2.3-hello", "1.2.3"},
+	}
+	for _, pair := range equal {
+		a, _ := NewPipVersion(pair[0])
+		b, _ := NewPipVersion(pair[1])
+		if cmp := a.(PipVersion).Compare(b.(PipVersion)); cmp != 0 {
+			t.Errorf("expected %q == %q, got compare result %d", pair[0], pair[1], cmp)
+		}
+	}
+}
+
+func TestPipVersion_Prerelease(t *testing.T) {
+	cases := map[string]bool{
+		"1.0": false, "1.0.post1": false, "1.0+local": false,
+		"1.0a1": true, "1.0rc1": true, "1.0.dev1": true, "1.0.post1.dev1": true,
+	}
+	for raw, expected := range cases {
+		version, err := NewPipVersion(raw)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if version.(PipVersion).Prerelease() != expected {
+			t.Errorf("expected %q pre-release to be %t", raw, expected)
+		}
+	}
+}
+
 func TestPipConstraints_Parts(t *testing.T) {
 	raw := ">=1.2.3,<=1.4.0,  !=1.2.17"
 	constr, err := NewPipConstraints(raw)
@@ -112,6 +169,21 @@ func TestPipConstraintsAndVersion_MatchMethod(t *testing.T) {
 		{"~=0.0.0", "123.213.213", true},
 		{"~=0.0.0", "0.0.0", true},
 		{"~=*", "123.213.213", true},
+		// PEP 440 pre, post and development releases
+		{">=1.0", "1.0.post1", true},
+		{"==1.0.0", "1.0.post1", false},
+		{"==1.0.0", "1.0+local", true},
+		{">=1.0", "2.0rc1", false},
+		{">=2.0rc1", "2.0rc2", true},
+		{">=2.0rc1", "2.0", true},
+		{"<2.0", "2.0.dev1", false},
+		{"<2.0rc1", "2.0.dev1", true},
+		{">=2.0.dev0", "2.0.dev1", true},
+		{">1.0,<2", "1!1.5", false},
+		{"==1.2.3.4", "1.2.3.4", true},
+		{"==1.2.3.4", "1.2.3.5", false},
+		{">=1.2.3.4", "1.2.3.5", true},
+		{"==1.2.3", "1.2.3.1", false},
 	}
 
 	for _, tcase := range cases {
@@ -139,3 +211,20 @@ func TestPipConstraintsAndVersion_MatchMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizePipName(t *testing.T) {
+	cases := map[string]PipPackageName{
+		"Django":             "django",
+		"python_dateutil":    "python-dateutil",
+		"Python.DateUtil":    "python-dateutil",
+		"zope.interface":     "zope-interface",
+		"friendly-bard":      "friendly-bard",
+		"FRIENDLY__.-_Bard":  "friendly-bard",
+		" ruamel.yaml.clib ": "ruamel-yaml-clib",
+	}
+	for name, expected := range cases {
+		if got := NormalizePipName(name); got != expected {
+			t.Errorf("%q: expected %q, got %q", name, expected, got)
+		}
+	}
+}